@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command clusterregistry-controller-manager runs the controllers of the
+// cluster registry.
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/controller/clusterstatus"
+	"k8s.io/cluster-registry/pkg/inject"
+)
+
+var (
+	masterURL            string
+	kubeconfig           string
+	controllers          string
+	workers              int
+	resyncPeriod         time.Duration
+	probeInterval        time.Duration
+	probeTimeout         time.Duration
+	heartbeatGranularity time.Duration
+	leaderElection       inject.LeaderElectionOptions
+	metricsBindAddress   string
+	healthProbeAddress   string
+)
+
+// setUpSignalHandler registered for SIGTERM and SIGINT. A stop channel is returned
+// which is closed on one of these signals. If a second signal is caught, the program
+// is terminated with exit code 1.
+func setUpSignalHandler() (stopCh <-chan struct{}) {
+	stop := make(chan struct{})
+	c := make(chan os.Signal, 2)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		close(stop)
+		<-c
+		os.Exit(1) // second signal. Exit directly.
+	}()
+
+	return stop
+}
+
+// registerControllers registers the controllers of the cluster registry with
+// manager.
+func registerControllers(manager *inject.Manager) error {
+	return manager.Register("clusterstatus", func(m *inject.Manager) (inject.Runnable, error) {
+		return clusterstatus.NewController(m.ClusterRegistryClient, m.InformerFactory, probeInterval, probeTimeout, heartbeatGranularity), nil
+	})
+}
+
+func main() {
+	flag.Parse()
+
+	stopCh := setUpSignalHandler()
+
+	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		klog.Fatalf("Error building kubeconfig: %s", err.Error())
+	}
+
+	manager, err := inject.NewManager(cfg, inject.Options{
+		Controllers:            strings.Split(controllers, ","),
+		Workers:                workers,
+		ResyncPeriod:           resyncPeriod,
+		LeaderElection:         leaderElection,
+		MetricsBindAddress:     metricsBindAddress,
+		HealthProbeBindAddress: healthProbeAddress,
+	})
+	if err != nil {
+		klog.Fatalf("Error creating controller manager: %s", err.Error())
+	}
+	if err := registerControllers(manager); err != nil {
+		klog.Fatalf("Error registering controllers: %s", err.Error())
+	}
+
+	if err := manager.Run(stopCh); err != nil {
+		klog.Fatalf("Error running controller manager: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value provided in the default context in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&controllers, "controllers", "*", "A comma-separated list of the controllers to run. '*' enables all the controllers, 'foo' enables the controller named 'foo' and '-foo' disables it. The only controller is 'clusterstatus'.")
+	flag.IntVar(&workers, "workers", 2, "The number of workers of each controller.")
+	flag.DurationVar(&resyncPeriod, "resync-period", 30*time.Second, "The resync period of the informers.")
+	flag.DurationVar(&probeInterval, "probe-interval", clusterstatus.DefaultProbeInterval, "The interval between two probes of the API server of a cluster by the clusterstatus controller.")
+	flag.DurationVar(&probeTimeout, "probe-timeout", clusterstatus.DefaultProbeTimeout, "The timeout of the HTTP requests made by the clusterstatus controller.")
+	flag.DurationVar(&heartbeatGranularity, "heartbeat-granularity", clusterstatus.DefaultHeartbeatGranularity, "The minimum interval between two status writes for a cluster whose ClusterOK condition has not changed.")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address on which the Prometheus metrics are served at /metrics. Empty disables the metrics endpoint.")
+	flag.StringVar(&healthProbeAddress, "health-probe-bind-address", ":8081", "The address on which the liveness and readiness of the controller manager are served at /healthz and /readyz. Empty disables the health endpoints.")
+	leaderElection.AddFlags(flag.CommandLine)
+}
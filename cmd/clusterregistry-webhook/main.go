@@ -0,0 +1,99 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command clusterregistry-webhook serves the webhooks of the Cluster custom
+// resource over HTTPS.
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/webhook"
+)
+
+var (
+	bindAddress string
+	port        int
+	certFile    string
+	keyFile     string
+)
+
+// setUpSignalHandler registered for SIGTERM and SIGINT. A stop channel is returned
+// which is closed on one of these signals. If a second signal is caught, the program
+// is terminated with exit code 1.
+func setUpSignalHandler() (stopCh <-chan struct{}) {
+	stop := make(chan struct{})
+	c := make(chan os.Signal, 2)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-c
+		close(stop)
+		<-c
+		os.Exit(1) // second signal. Exit directly.
+	}()
+
+	return stop
+}
+
+func main() {
+	flag.Parse()
+
+	if certFile == "" || keyFile == "" {
+		klog.Fatal("--tls-cert-file and --tls-private-key-file are required")
+	}
+
+	stopCh := setUpSignalHandler()
+
+	mux := http.NewServeMux()
+	mux.Handle(webhook.ConversionPath, webhook.NewConversionHandler())
+	mux.Handle(webhook.ValidationPath, webhook.NewValidationHandler())
+	mux.Handle(webhook.DefaultingPath, webhook.NewDefaultingHandler())
+
+	server := &http.Server{
+		Addr:    net.JoinHostPort(bindAddress, strconv.Itoa(port)),
+		Handler: mux,
+	}
+	go func() {
+		<-stopCh
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			klog.Errorf("Error shutting down webhook server: %s", err.Error())
+		}
+	}()
+
+	klog.Infof("Serving webhooks on %s", server.Addr)
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != http.ErrServerClosed {
+		klog.Fatalf("Error serving webhooks: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.StringVar(&bindAddress, "bind-address", "", "The IP address on which to serve the webhooks. Defaults to all interfaces.")
+	flag.IntVar(&port, "port", 8443, "The port on which to serve the webhooks.")
+	flag.StringVar(&certFile, "tls-cert-file", "", "Path to the PEM-encoded serving certificate.")
+	flag.StringVar(&keyFile, "tls-private-key-file", "", "Path to the PEM-encoded private key of the serving certificate.")
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command export-kubeconfig renders Clusters from a cluster registry into a
+// kubeconfig file.
+package main
+
+import (
+	"flag"
+	"net"
+	"os"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	"k8s.io/cluster-registry/pkg/kubeconfig"
+)
+
+var (
+	masterURL        string
+	registryConfig   string
+	namespace        string
+	selector         string
+	clientIP         string
+	embedCredentials bool
+	output           string
+)
+
+func main() {
+	flag.Parse()
+
+	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, registryConfig)
+	if err != nil {
+		klog.Fatalf("Error building kubeconfig: %s", err.Error())
+	}
+
+	clusterClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Error building cluster clientset: %s", err.Error())
+	}
+
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		klog.Fatalf("Error parsing selector: %s", err.Error())
+	}
+
+	options := kubeconfig.ExportOptions{EmbedCredentials: embedCredentials}
+	if clientIP != "" {
+		if options.ClientIP = net.ParseIP(clientIP); options.ClientIP == nil {
+			klog.Fatalf("Invalid client IP %q", clientIP)
+		}
+	}
+	if embedCredentials {
+		if options.KubeClient, err = kubernetes.NewForConfig(cfg); err != nil {
+			klog.Fatalf("Error building kubernetes clientset: %s", err.Error())
+		}
+	}
+
+	config, err := kubeconfig.ExportFromRegistry(clusterClient, namespace, labelSelector, options)
+	if err != nil {
+		klog.Fatalf("Error exporting clusters: %s", err.Error())
+	}
+
+	if output != "" {
+		err = clientcmd.WriteToFile(*config, output)
+	} else {
+		var data []byte
+		if data, err = clientcmd.Write(*config); err == nil {
+			_, err = os.Stdout.Write(data)
+		}
+	}
+	if err != nil {
+		klog.Fatalf("Error writing kubeconfig: %s", err.Error())
+	}
+}
+
+func init() {
+	flag.StringVar(&registryConfig, "kubeconfig", "", "Path to a kubeconfig for the cluster registry. Only required if out-of-cluster.")
+	flag.StringVar(&masterURL, "master", "", "The address of the cluster registry's Kubernetes API server. Overrides any value provided in the default context in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&namespace, "namespace", "", "The namespace of the Clusters to export. Defaults to all namespaces.")
+	flag.StringVar(&selector, "selector", "", "A label selector restricting the Clusters to export.")
+	flag.StringVar(&clientIP, "client-ip", "", "The IP used to select the server address of each Cluster. Defaults to the addresses of the local host.")
+	flag.BoolVar(&embedCredentials, "embed-credentials", false, "Embed the credentials referenced by the AuthInfo.Controller of each Cluster in the kubeconfig.")
+	flag.StringVar(&output, "output", "", "Path of the kubeconfig to write. Defaults to stdout.")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command import-kubeconfig creates or updates Clusters in a cluster registry
+// from the contexts of one or more kubeconfig files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	"k8s.io/cluster-registry/pkg/kubeconfig"
+)
+
+var (
+	masterURL      string
+	registryConfig string
+	namespace      string
+	createSecrets  bool
+	overwrite      bool
+	dryRun         bool
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] KUBECONFIG...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := clientcmd.BuildConfigFromFlags(masterURL, registryConfig)
+	if err != nil {
+		klog.Fatalf("Error building kubeconfig: %s", err.Error())
+	}
+
+	clusterClient, err := clientset.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Error building cluster clientset: %s", err.Error())
+	}
+
+	options := kubeconfig.ImportOptions{
+		Namespace:     namespace,
+		CreateSecrets: createSecrets,
+		Overwrite:     overwrite,
+		DryRun:        dryRun,
+	}
+	if createSecrets {
+		if options.KubeClient, err = kubernetes.NewForConfig(cfg); err != nil {
+			klog.Fatalf("Error building kubernetes clientset: %s", err.Error())
+		}
+	}
+
+	results, err := kubeconfig.ImportFiles(clusterClient, flag.Args(), options)
+	failed := err != nil
+	for _, result := range results {
+		fmt.Println(result)
+		if result.Action == kubeconfig.ImportConflict || result.Action == kubeconfig.ImportFailed {
+			failed = true
+		}
+	}
+	if err != nil {
+		klog.Errorf("Error importing kubeconfig: %s", err.Error())
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	flag.StringVar(&registryConfig, "kubeconfig", "", "Path to a kubeconfig for the cluster registry. Only required if out-of-cluster.")
+	flag.StringVar(&masterURL, "master", "", "The address of the cluster registry's Kubernetes API server. Overrides any value provided in the default context in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&namespace, "namespace", "default", "The namespace in which to create Clusters and Secrets.")
+	flag.BoolVar(&createSecrets, "create-secrets", false, "Store the static credentials of each context in a Secret referenced by the AuthInfo.Controller of its Cluster.")
+	flag.BoolVar(&overwrite, "overwrite", false, "Update existing Clusters and Secrets that differ from the imported ones instead of reporting a conflict.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Report what would be done without writing anything.")
+}
@@ -23,19 +23,20 @@ import (
 	"syscall"
 	"time"
 
-	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 
-	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
-	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+	"k8s.io/cluster-registry/pkg/inject"
 )
 
 var (
-	masterURL  string
-	kubeconfig string
-	slackURL   string
+	masterURL          string
+	kubeconfig         string
+	slackURL           string
+	leaderElection     inject.LeaderElectionOptions
+	metricsBindAddress string
+	healthProbeAddress string
 )
 
 // setUpSignalHandler registered for SIGTERM and SIGINT. A stop channel is returned
@@ -65,22 +66,24 @@ func main() {
 		klog.Fatalf("Error building kubeconfig: %s", err.Error())
 	}
 
-	kubeClient, err := kubernetes.NewForConfig(cfg)
+	manager, err := inject.NewManager(cfg, inject.Options{
+		Component:              controllerAgentName,
+		Workers:                2,
+		ResyncPeriod:           time.Second * 30,
+		LeaderElection:         leaderElection,
+		MetricsBindAddress:     metricsBindAddress,
+		HealthProbeBindAddress: healthProbeAddress,
+	})
 	if err != nil {
-		klog.Fatalf("Error building kubernetes clientset: %s", err.Error())
+		klog.Fatalf("Error creating controller manager: %s", err.Error())
 	}
-	clusterClient, err := clientset.NewForConfig(cfg)
-	if err != nil {
-		klog.Fatalf("Error building cluster clientset: %s", err.Error())
+	if err := manager.Register("slack", func(m *inject.Manager) (inject.Runnable, error) {
+		return NewSlackController(m.KubeClient, m.ClusterRegistryClient, m.InformerFactory, m.Recorder, slackURL), nil
+	}); err != nil {
+		klog.Fatalf("Error registering controller: %s", err.Error())
 	}
 
-	clusterInformerFactory := informers.NewSharedInformerFactory(clusterClient, time.Second*30)
-
-	controller := NewSlackController(kubeClient, clusterClient, clusterInformerFactory, slackURL)
-
-	go clusterInformerFactory.Start(stopCh)
-
-	if err = controller.Run(2, stopCh); err != nil {
+	if err = manager.Run(stopCh); err != nil {
 		klog.Fatalf("Error running controller: %s", err.Error())
 	}
 }
@@ -89,4 +92,7 @@ func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value provided in the default context in kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&slackURL, "slack-url", "", "The URL of a Slack Incoming Webhook to which messages will be posted. Must be non-empty, or this controller will be ineffectual. See https://api.slack.com/incoming-webhooks.")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", "", "The address, e.g. ':8080', on which the Prometheus metrics are served at /metrics. Empty disables the metrics endpoint.")
+	flag.StringVar(&healthProbeAddress, "health-probe-bind-address", "", "The address, e.g. ':8081', on which the liveness and readiness of the controller are served at /healthz and /readyz. Empty disables the health endpoints.")
+	leaderElection.AddFlags(flag.CommandLine)
 }
@@ -20,26 +20,22 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
-	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
 	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
-	clusterregistryscheme "k8s.io/cluster-registry/pkg/client/clientset/versioned/scheme"
 	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
 	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/controller"
 )
 
 const controllerAgentName = "clusterregistry-controller"
@@ -55,20 +51,14 @@ const (
 
 // Controller is the controller implementation for Cluster resources
 type Controller struct {
+	*controller.Controller
+
 	// kubeclientset is a standard kubernetes clientset
 	kubeclientset kubernetes.Interface
 	// clusterregistryclientset is a clientset for our own API group
 	clusterregistryclientset clientset.Interface
 
-	clusterLister  listers.ClusterLister
-	clustersSynced cache.InformerSynced
-
-	// workqueue is a rate limited work queue. This is used to queue work to be
-	// processed instead of performing it as soon as a change happens. This
-	// means we can ensure we only process a fixed amount of resources at a
-	// time, and makes it easy to ensure we are never processing the same item
-	// simultaneously in two different workers.
-	workqueue workqueue.RateLimitingInterface
+	clusterLister listers.ClusterLister
 
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
@@ -84,139 +74,36 @@ func NewSlackController(
 	kubeclientset kubernetes.Interface,
 	clusterregistryclientset clientset.Interface,
 	clusterregistryInformerFactory informers.SharedInformerFactory,
+	recorder record.EventRecorder,
 	slackURL string) *Controller {
 
 	// obtain references to shared index informers for the Cluster type.
 	clusterInformer := clusterregistryInformerFactory.Clusterregistry().V1alpha1().Clusters()
 
-	// Create event broadcaster
-	// Add clusterregistry-controller types to the default Kubernetes Scheme so Events can be
-	// logged for clusterregistry-controller types.
-	clusterregistryscheme.AddToScheme(scheme.Scheme)
-	klog.V(4).Info("Creating event broadcaster")
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(klog.Infof)
-	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
-	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
-
-	controller := &Controller{
+	c := &Controller{
 		kubeclientset:            kubeclientset,
 		clusterregistryclientset: clusterregistryclientset,
 		clusterLister:            clusterInformer.Lister(),
-		clustersSynced:           clusterInformer.Informer().HasSynced,
-		workqueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Clusters"),
 		recorder:                 recorder,
 		slackURL:                 slackURL,
 	}
+	c.Controller = controller.New("Clusters", c.syncHandler, controller.Options{})
 
 	klog.Info("Setting up event handlers")
-	clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    controller.enqueueCluster,
-		DeleteFunc: controller.enqueueCluster,
-	})
-
-	return controller
-}
-
-// Run will set up the event handlers for types we are interested in, as well
-// as syncing informer caches and starting workers. It will block until stopCh
-// is closed, at which point it will shutdown the workqueue and wait for
-// workers to finish processing their current work items.
-func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
-	defer runtime.HandleCrash()
-	defer c.workqueue.ShutDown()
+	// Only additions and removals are posted, so updates are ignored.
+	c.WatchClusters(clusterInformer, func(_, _ *v1alpha1.Cluster) bool { return false })
 
-	// Start the informer factories to begin populating the informer caches
-	klog.Info("Starting Cluster controller")
-
-	// Wait for the caches to be synced before starting workers
-	klog.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(stopCh, c.clustersSynced); !ok {
-		return errors.New("failed to wait for caches to sync")
-	}
-
-	klog.Info("Starting workers")
-	// Launch two workers to process Cluster resources
-	for i := 0; i < threadiness; i++ {
-		go wait.Until(c.runWorker, time.Second, stopCh)
-	}
-
-	klog.Info("Started workers")
-	<-stopCh
-	klog.Info("Shutting down workers")
-
-	return nil
-}
-
-// runWorker is a long-running function that will continually call the
-// processNextWorkItem function in order to read and process a message on the
-// workqueue.
-func (c *Controller) runWorker() {
-	for c.processNextWorkItem() {
-	}
-}
-
-// processNextWorkItem will read a single work item off the workqueue and
-// attempt to process it, by calling the syncHandler.
-func (c *Controller) processNextWorkItem() bool {
-	obj, shutdown := c.workqueue.Get()
-
-	if shutdown {
-		return false
-	}
-
-	// We wrap this block in a func so we can defer c.workqueue.Done.
-	err := func(obj interface{}) error {
-		// We call Done here so the workqueue knows we have finished
-		// processing this item. We also must remember to call Forget if we
-		// do not want this work item being re-queued. For example, we do
-		// not call Forget if a transient error occurs, instead the item is
-		// put back on the workqueue and attempted again after a back-off
-		// period.
-		defer c.workqueue.Done(obj)
-		var key string
-		var ok bool
-		// We expect strings to come off the workqueue. These are of the
-		// form namespace/name. We do this as the delayed nature of the
-		// workqueue means the items in the informer cache may actually be
-		// more up to date that when the item was initially put onto the
-		// workqueue.
-		if key, ok = obj.(string); !ok {
-			// As the item in the workqueue is actually invalid, we call
-			// Forget here else we'd go into a loop of attempting to
-			// process a work item that is invalid.
-			c.workqueue.Forget(obj)
-			runtime.HandleError(errors.Errorf("expected string in workqueue but got %#v", obj))
-			return nil
-		}
-		// Run the syncHandler, passing it the namespace/name string of the
-		// cluster resource to be synced.
-		if err := c.syncHandler(key); err != nil {
-			return errors.Wrapf(err, "error syncing '%s'", key)
-		}
-		// Finally, if no error occurs we Forget this item so it does not
-		// get queued again until another change happens.
-		c.workqueue.Forget(obj)
-		klog.Infof("Successfully synced '%s'", key)
-		return nil
-	}(obj)
-
-	if err != nil {
-		runtime.HandleError(err)
-		return true
-	}
-
-	return true
+	return c
 }
 
 // syncHandler sends Slack notifications when the cluster is updated.
-func (c *Controller) syncHandler(key string) error {
-	klog.Infof(key)
+func (c *Controller) syncHandler(key string) (controller.Result, error) {
+	klog.Info(key)
 	// Convert the namespace/name string into a distinct namespace and name
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		runtime.HandleError(errors.Errorf("invalid resource key: %s", key))
-		return nil
+		return controller.Result{}, nil
 	}
 
 	bodyFormatString := "Cluster %s was added in namespace %s."
@@ -228,7 +115,7 @@ func (c *Controller) syncHandler(key string) error {
 		if apierrors.IsNotFound(err) {
 			bodyFormatString = "Cluster %s was removed from namespace %s."
 		} else {
-			return err
+			return controller.Result{}, err
 		}
 	}
 
@@ -236,25 +123,12 @@ func (c *Controller) syncHandler(key string) error {
 	client := &http.Client{}
 	resp, err := client.Post(c.slackURL, "application/json", body)
 	if err != nil {
-		return err
+		return controller.Result{}, err
 	}
 	klog.V(4).Infof("%#v", resp)
 
 	if cluster != nil {
 		c.recorder.Event(cluster, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
 	}
-	return nil
-}
-
-// enqueueCluster takes a Cluster resource and converts it into a namespace/name
-// string which is then put onto the work queue. This method should *not* be
-// passed resources of any type other than Cluster.
-func (c *Controller) enqueueCluster(obj interface{}) {
-	var key string
-	var err error
-	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
-		runtime.HandleError(err)
-		return
-	}
-	c.workqueue.AddRateLimited(key)
+	return controller.Result{}, nil
 }
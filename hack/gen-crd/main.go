@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command gen-crd turns the Cluster CustomResourceDefinition generated by
+// controller-gen into cluster-registry-crd.yaml and into the Go source that
+// defines clusterregistry.ClusterCRD, so that both are produced from the same
+// source. It is run by hack/update-crd.sh.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/klog"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+var (
+	input    string
+	yamlFile string
+	goFile   string
+)
+
+const goTemplate = `/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by gen-crd. DO NOT EDIT.
+
+package clusterregistry
+
+// clusterCRDYAML is the Cluster CustomResourceDefinition, as written to
+// cluster-registry-crd.yaml.
+const clusterCRDYAML = ` + "`%s`" + `
+`
+
+func main() {
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(input)
+	if err != nil {
+		klog.Fatalf("Error reading %s: %s", input, err.Error())
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, crd); err != nil {
+		klog.Fatalf("Error decoding %s: %s", input, err.Error())
+	}
+
+	setConversion(crd)
+
+	out, err := encode(crd)
+	if err != nil {
+		klog.Fatalf("Error encoding CustomResourceDefinition: %s", err.Error())
+	}
+	if bytes.Contains(out, []byte("`")) {
+		klog.Fatal("The CustomResourceDefinition contains a backquote, which cannot be embedded in Go source")
+	}
+
+	if err := ioutil.WriteFile(yamlFile, out, 0644); err != nil {
+		klog.Fatalf("Error writing %s: %s", yamlFile, err.Error())
+	}
+	if err := ioutil.WriteFile(goFile, []byte(fmt.Sprintf(goTemplate, out)), 0644); err != nil {
+		klog.Fatalf("Error writing %s: %s", goFile, err.Error())
+	}
+}
+
+// setConversion configures how crd converts between versions. v1alpha1 and
+// v1beta1 have the same schema, so the API server converts between them by
+// only changing the apiVersion, and the CRD works without
+// cmd/clusterregistry-webhook. Once the schemas diverge, this must switch to
+// the Webhook strategy, calling the conversion webhook that
+// cmd/clusterregistry-webhook serves at /convert.
+func setConversion(crd *apiextensionsv1.CustomResourceDefinition) {
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.NoneConverter,
+	}
+}
+
+// encode returns the YAML encoding of crd, without the fields that are only
+// meaningful for objects read back from an API server.
+func encode(crd *apiextensionsv1.CustomResourceDefinition) ([]byte, error) {
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	delete(object, "status")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+
+	out, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	return []byte("---\n" + strings.TrimSpace(string(out)) + "\n"), nil
+}
+
+func init() {
+	flag.StringVar(&input, "input", "", "Path to the CustomResourceDefinition generated by controller-gen.")
+	flag.StringVar(&yamlFile, "yaml", "cluster-registry-crd.yaml", "Path of the YAML file to write.")
+	flag.StringVar(&goFile, "go", "pkg/apis/clusterregistry/zz_generated.crd.go", "Path of the Go file to write.")
+}
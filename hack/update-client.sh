@@ -0,0 +1,54 @@
+#!/usr/bin/env bash
+# Copyright 2018 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates the apply configurations, clientset, listers and informers of the
+# clusterregistry API in pkg/client.
+#
+# Requires applyconfiguration-gen, client-gen, lister-gen and informer-gen
+# (k8s.io/code-generator/cmd/... v0.26) in the PATH, and this repository to be
+# checked out in the GOPATH.
+#
+# Usage:
+#    $0
+
+set -euo pipefail
+
+REPO_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+GOPATH_SRC="$(go env GOPATH | cut -d: -f1)/src"
+
+API_PACKAGES="k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1,k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+CLIENT_PACKAGE="k8s.io/cluster-registry/pkg/client"
+COMMON_FLAGS=(--go-header-file "${REPO_ROOT}/hack/boilerplate.go.txt" --output-base "${GOPATH_SRC}")
+
+applyconfiguration-gen "${COMMON_FLAGS[@]}" \
+  --input-dirs "${API_PACKAGES}" \
+  --output-package "${CLIENT_PACKAGE}/applyconfiguration"
+
+client-gen "${COMMON_FLAGS[@]}" \
+  --clientset-name versioned \
+  --input-base "" \
+  --input "${API_PACKAGES}" \
+  --apply-configuration-package "${CLIENT_PACKAGE}/applyconfiguration" \
+  --output-package "${CLIENT_PACKAGE}/clientset"
+
+lister-gen "${COMMON_FLAGS[@]}" \
+  --input-dirs "${API_PACKAGES}" \
+  --output-package "${CLIENT_PACKAGE}/listers"
+
+informer-gen "${COMMON_FLAGS[@]}" \
+  --input-dirs "${API_PACKAGES}" \
+  --versioned-clientset-package "${CLIENT_PACKAGE}/clientset/versioned" \
+  --listers-package "${CLIENT_PACKAGE}/listers" \
+  --output-package "${CLIENT_PACKAGE}/informers"
@@ -0,0 +1,41 @@
+#!/usr/bin/env bash
+# Copyright 2018 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates the Cluster CustomResourceDefinition from the markers and doc
+# comments in the clusterregistry API types, and writes it to
+# cluster-registry-crd.yaml and pkg/apis/clusterregistry/zz_generated.crd.go.
+#
+# Requires controller-gen (sigs.k8s.io/controller-tools/cmd/controller-gen) in
+# the PATH.
+#
+# Usage:
+#    $0
+
+set -euo pipefail
+
+REPO_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+cd "${REPO_ROOT}"
+
+TMP_DIR="$(mktemp -d)"
+trap 'rm -rf "${TMP_DIR}"' EXIT
+
+controller-gen crd:crdVersions=v1 \
+  paths=./pkg/apis/clusterregistry/... \
+  output:crd:dir="${TMP_DIR}"
+
+go run ./hack/gen-crd \
+  --input "${TMP_DIR}/clusterregistry.k8s.io_clusters.yaml" \
+  --yaml cluster-registry-crd.yaml \
+  --go pkg/apis/clusterregistry/zz_generated.crd.go
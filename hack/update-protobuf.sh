@@ -0,0 +1,39 @@
+#!/usr/bin/env bash
+# Copyright 2018 The Kubernetes Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates the protobuf IDL (generated.proto) and the protobuf marshalling
+# code (generated.pb.go) of the clusterregistry API types from their
+# `protobuf` struct tags.
+#
+# Requires go-to-protobuf and protoc-gen-gogo
+# (k8s.io/code-generator/cmd/go-to-protobuf/...) and protoc 3.x in the PATH,
+# and this repository, k8s.io/apimachinery and github.com/gogo/protobuf to be
+# checked out in the GOPATH.
+#
+# Usage:
+#    $0
+
+set -euo pipefail
+
+REPO_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+GOPATH_SRC="$(go env GOPATH | cut -d: -f1)/src"
+
+go-to-protobuf \
+  --go-header-file "${REPO_ROOT}/hack/boilerplate.go.txt" \
+  --output-base "${GOPATH_SRC}" \
+  --proto-import "${GOPATH_SRC}" \
+  --proto-import "${GOPATH_SRC}/github.com/gogo/protobuf/protobuf" \
+  --apimachinery-packages "-k8s.io/apimachinery/pkg/util/intstr,-k8s.io/apimachinery/pkg/api/resource,-k8s.io/apimachinery/pkg/runtime/schema,-k8s.io/apimachinery/pkg/runtime,-k8s.io/apimachinery/pkg/apis/meta/v1" \
+  --packages "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1,k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
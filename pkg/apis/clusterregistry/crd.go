@@ -0,0 +1,37 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterregistry
+
+import (
+	"github.com/ghodss/yaml"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ClusterCRD is the CustomResourceDefinition of the Cluster resource. It is
+// generated by hack/update-crd.sh from the markers and doc comments of the
+// v1alpha1 and v1beta1 types, together with cluster-registry-crd.yaml.
+// Callers that modify it must work on a copy.
+var ClusterCRD = mustDecodeCRD(clusterCRDYAML)
+
+func mustDecodeCRD(data string) apiextensionsv1.CustomResourceDefinition {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal([]byte(data), &crd); err != nil {
+		panic(err)
+	}
+	return crd
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterregistry
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestClusterCRDMatchesYAML(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "cluster-registry-crd.yaml"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != clusterCRDYAML {
+		t.Errorf("cluster-registry-crd.yaml is out of date with ClusterCRD; run hack/update-crd.sh")
+	}
+}
+
+func TestClusterCRDVersions(t *testing.T) {
+	versions := map[string]bool{}
+	var storage []string
+	for _, version := range ClusterCRD.Spec.Versions {
+		versions[version.Name] = version.Served
+		if version.Storage {
+			storage = append(storage, version.Name)
+		}
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			t.Errorf("Version %s has no schema", version.Name)
+		}
+	}
+	for _, name := range []string{"v1alpha1", "v1beta1"} {
+		if !versions[name] {
+			t.Errorf("Expected version %s to be served", name)
+		}
+	}
+	if len(storage) != 1 || storage[0] != "v1beta1" {
+		t.Errorf("Expected v1beta1 to be the only storage version, got %v", storage)
+	}
+	// The versions have the same schema, so the CRD must not depend on the
+	// conversion webhook.
+	if ClusterCRD.Spec.Conversion == nil || ClusterCRD.Spec.Conversion.Strategy != apiextensionsv1.NoneConverter {
+		t.Errorf("Expected no conversion, got %v", ClusterCRD.Spec.Conversion)
+	}
+}
+
+// TestClusterCRDStructural checks that every node of the schemas specifies a
+// type, which is required of structural schemas, and that the fields under
+// spec and status are documented.
+func TestClusterCRDStructural(t *testing.T) {
+	var check func(path string, schema *apiextensionsv1.JSONSchemaProps, documented bool)
+	check = func(path string, schema *apiextensionsv1.JSONSchemaProps, documented bool) {
+		if schema.Type == "" && !schema.XIntOrString {
+			t.Errorf("%s has no type", path)
+		}
+		if documented && schema.Description == "" {
+			t.Errorf("%s has no description", path)
+		}
+		for name, property := range schema.Properties {
+			property := property
+			check(path+"."+name, &property, documented || name == "spec" || name == "status")
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			check(path+"[]", schema.Items.Schema, documented)
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			check(path+"{}", schema.AdditionalProperties.Schema, false)
+		}
+	}
+
+	for _, version := range ClusterCRD.Spec.Versions {
+		check(version.Name, version.Schema.OpenAPIV3Schema, false)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterregistry_test
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+const (
+	fuzzIterations = 1000
+
+	protobufMediaType = "application/vnd.kubernetes.protobuf"
+)
+
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.New().RandSource(rand.NewSource(seed)).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(t *metav1.Time, c fuzz.Continue) {
+			// Both the JSON and the protobuf serializations of times truncate
+			// them to seconds.
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+		func(tm *metav1.TypeMeta, c fuzz.Continue) {
+			// TypeMeta is carried by the envelope of the protobuf
+			// serialization, not by the messages.
+			*tm = metav1.TypeMeta{}
+		},
+	)
+}
+
+func newProtobufSerializer(t *testing.T) (serializer.CodecFactory, runtime.Serializer) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	codecs := serializer.NewCodecFactory(scheme)
+	info, ok := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), protobufMediaType)
+	if !ok {
+		t.Fatalf("Expected the codecs to support %s", protobufMediaType)
+	}
+	return codecs, info.Serializer
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+	codecs, protobuf := newProtobufSerializer(t)
+
+	tests := []struct {
+		version schema.GroupVersion
+		newObj  func() runtime.Object
+	}{
+		{v1alpha1.SchemeGroupVersion, func() runtime.Object { return &v1alpha1.Cluster{} }},
+		{v1alpha1.SchemeGroupVersion, func() runtime.Object { return &v1alpha1.ClusterList{} }},
+		{v1beta1.SchemeGroupVersion, func() runtime.Object { return &v1beta1.Cluster{} }},
+		{v1beta1.SchemeGroupVersion, func() runtime.Object { return &v1beta1.ClusterList{} }},
+	}
+
+	for i, tc := range tests {
+		f := newFuzzer(int64(i))
+		encoder := codecs.EncoderForVersion(protobuf, tc.version)
+		for j := 0; j < fuzzIterations; j++ {
+			original := tc.newObj()
+			f.Fuzz(original)
+
+			data, err := runtime.Encode(encoder, original)
+			if err != nil {
+				t.Fatalf("Unexpected error encoding %T: %v", original, err)
+			}
+			decoded, _, err := protobuf.Decode(data, nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error decoding %T: %v", original, err)
+			}
+			decoded.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+			if !equality.Semantic.DeepEqual(original, decoded) {
+				t.Fatalf("Protobuf round trip changed the %T:\n%s", original, diff.ObjectReflectDiff(original, decoded))
+			}
+		}
+	}
+}
+
+// TestProtobufMarshal checks the generated marshalling code without the
+// envelope added by the protobuf serializer.
+func TestProtobufMarshal(t *testing.T) {
+	f := newFuzzer(0)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1beta1.Cluster{}
+		f.Fuzz(original)
+
+		data, err := original.Marshal()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(data) != original.Size() {
+			t.Fatalf("Expected %d bytes, got %d", original.Size(), len(data))
+		}
+		decoded := &v1beta1.Cluster{}
+		if err := decoded.Unmarshal(data); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(original, decoded) {
+			t.Fatalf("Protobuf round trip changed the Cluster:\n%s", diff.ObjectReflectDiff(original, decoded))
+		}
+	}
+}
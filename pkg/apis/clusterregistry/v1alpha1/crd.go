@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry"
+)
+
+// ClusterCRD is the CustomResourceDefinition of the Cluster resource, as an
+// apiextensions.k8s.io/v1beta1 object converted from clusterregistry.ClusterCRD.
+//
+// Deprecated: use k8s.io/cluster-registry/pkg/apis/clusterregistry.ClusterCRD,
+// an apiextensions.k8s.io/v1 object. Kubernetes 1.22 and later do not serve
+// apiextensions.k8s.io/v1beta1. ClusterCRD will be removed in the next
+// release.
+var ClusterCRD = mustConvertCRD(&clusterregistry.ClusterCRD)
+
+// mustConvertCRD converts an apiextensions.k8s.io/v1 CRD to v1beta1.
+func mustConvertCRD(in interface{}) v1beta1.CustomResourceDefinition {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	internal := &apiextensions.CustomResourceDefinition{}
+	if err := scheme.Convert(in, internal, nil); err != nil {
+		panic(err)
+	}
+	out := v1beta1.CustomResourceDefinition{}
+	if err := scheme.Convert(internal, &out, nil); err != nil {
+		panic(err)
+	}
+	return out
+}
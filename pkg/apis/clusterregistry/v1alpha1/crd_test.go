@@ -0,0 +1,43 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry"
+)
+
+func TestClusterCRD(t *testing.T) {
+	if ClusterCRD.Name != clusterregistry.ClusterCRD.Name {
+		t.Errorf("Expected name %q, got %q", clusterregistry.ClusterCRD.Name, ClusterCRD.Name)
+	}
+	if ClusterCRD.Spec.Group != "clusterregistry.k8s.io" || ClusterCRD.Spec.Names.Kind != "Cluster" {
+		t.Errorf("Unexpected group %q and kind %q", ClusterCRD.Spec.Group, ClusterCRD.Spec.Names.Kind)
+	}
+	if len(ClusterCRD.Spec.Versions) != len(clusterregistry.ClusterCRD.Spec.Versions) {
+		t.Fatalf("Expected %d versions, got %d", len(clusterregistry.ClusterCRD.Spec.Versions), len(ClusterCRD.Spec.Versions))
+	}
+	for i, version := range ClusterCRD.Spec.Versions {
+		if version.Name != clusterregistry.ClusterCRD.Spec.Versions[i].Name {
+			t.Errorf("Expected version %q, got %q", clusterregistry.ClusterCRD.Spec.Versions[i].Name, version.Name)
+		}
+	}
+	if ClusterCRD.Spec.Validation == nil && ClusterCRD.Spec.Versions[0].Schema == nil {
+		t.Errorf("Expected the schema to be converted")
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultServerPort is the port added to server addresses that do not
+	// specify one.
+	DefaultServerPort = "443"
+
+	// AllIPv4ClientCIDR and AllIPv6ClientCIDR are the client CIDRs that the
+	// endpoint of a cluster with a single endpoint defaults to.
+	AllIPv4ClientCIDR = "0.0.0.0/0"
+	AllIPv6ClientCIDR = "::/0"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_Cluster sets the default values of the fields of obj:
+//
+//   - the https:// scheme is removed from, and the default port added to, the
+//     ServerAddress of each endpoint, so that it has the form host:port;
+//   - a ClientCIDR that is a bare IP address, such as the legacy 0.0.0.0, is
+//     replaced by the equivalent CIDR; see NormalizeClientCIDR;
+//   - if obj has a single endpoint without a ClientCIDR, it is replaced by one
+//     endpoint with the ClientCIDR 0.0.0.0/0 and one with ::/0, both with the
+//     same ServerAddress;
+//   - the Namespace of the references in AuthInfo defaults to the namespace
+//     of obj.
+func SetDefaults_Cluster(obj *Cluster) {
+	endpoints := obj.Spec.KubernetesAPIEndpoints.ServerEndpoints
+	for i := range endpoints {
+		endpoints[i].ServerAddress = NormalizeServerAddress(endpoints[i].ServerAddress)
+		endpoints[i].ClientCIDR = NormalizeClientCIDR(endpoints[i].ClientCIDR)
+	}
+	if len(endpoints) == 1 && endpoints[0].ClientCIDR == "" {
+		obj.Spec.KubernetesAPIEndpoints.ServerEndpoints = []ServerAddressByClientCIDR{
+			{ClientCIDR: AllIPv4ClientCIDR, ServerAddress: endpoints[0].ServerAddress},
+			{ClientCIDR: AllIPv6ClientCIDR, ServerAddress: endpoints[0].ServerAddress},
+		}
+	}
+
+	for _, ref := range []*ObjectReference{obj.Spec.AuthInfo.User, obj.Spec.AuthInfo.Controller} {
+		if ref != nil && ref.Namespace == "" {
+			ref.Namespace = obj.Namespace
+		}
+	}
+}
+
+// NormalizeServerAddress returns address in the form host:port if it is an
+// https URL without a path, or a hostname or IP with or without a port.
+// Other addresses, e.g. http URLs and unparseable addresses, are returned
+// unchanged.
+func NormalizeServerAddress(address string) string {
+	if address == "" {
+		return address
+	}
+	raw := address
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return address
+	}
+	if !strings.HasPrefix(u.Host, "[") && strings.Contains(u.Hostname(), ":") {
+		// An IPv6 address without brackets, whose port cannot be told apart
+		// from the address.
+		return address
+	}
+	port := u.Port()
+	if port == "" {
+		port = DefaultServerPort
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// NormalizeClientCIDR returns the CIDR equivalent to cidr if it is a bare IP
+// address: 0.0.0.0/0 or ::/0 for the unspecified addresses 0.0.0.0 and ::,
+// which used to match every client, and a CIDR containing only the address
+// otherwise. Other values, including CIDRs and unparseable values, are
+// returned unchanged.
+func NormalizeClientCIDR(cidr string) string {
+	if strings.Contains(cidr, "/") {
+		return cidr
+	}
+	ip := net.ParseIP(cidr)
+	if ip == nil {
+		return cidr
+	}
+	bits := net.IPv6len * 8
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, net.IPv4len*8
+	}
+	ones := bits
+	if ip.IsUnspecified() {
+		ones = 0
+	}
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(ones, bits)}).String()
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNormalizeServerAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"", ""},
+		{"cluster.example.com", "cluster.example.com:443"},
+		{"cluster.example.com:6443", "cluster.example.com:6443"},
+		{"https://cluster.example.com", "cluster.example.com:443"},
+		{"https://cluster.example.com:6443/", "cluster.example.com:6443"},
+		{"10.0.0.1", "10.0.0.1:443"},
+		{"[2001:db8::1]", "[2001:db8::1]:443"},
+		{"https://[2001:db8::1]:6443", "[2001:db8::1]:6443"},
+		// Addresses that cannot be written as host:port are left alone.
+		{"http://cluster.example.com", "http://cluster.example.com"},
+		{"https://cluster.example.com/prefix", "https://cluster.example.com/prefix"},
+		{"https://user@cluster.example.com", "https://user@cluster.example.com"},
+		{"2001:db8::1", "2001:db8::1"},
+	}
+
+	for _, tc := range tests {
+		if got := NormalizeServerAddress(tc.address); got != tc.want {
+			t.Errorf("NormalizeServerAddress(%q) = %q, want %q", tc.address, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeClientCIDR(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want string
+	}{
+		{"", ""},
+		{"10.0.0.0/8", "10.0.0.0/8"},
+		{"0.0.0.0", "0.0.0.0/0"},
+		{"::", "::/0"},
+		{"10.1.2.3", "10.1.2.3/32"},
+		{"2001:db8::1", "2001:db8::1/128"},
+		{"public", "public"},
+	}
+
+	for _, tc := range tests {
+		if got := NormalizeClientCIDR(tc.cidr); got != tc.want {
+			t.Errorf("NormalizeClientCIDR(%q) = %q, want %q", tc.cidr, got, tc.want)
+		}
+	}
+}
+
+func TestSetDefaultsCluster(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []ServerAddressByClientCIDR
+		authInfo  AuthInfo
+		want      ClusterSpec
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "single endpoint without CIDR",
+			endpoints: []ServerAddressByClientCIDR{{ServerAddress: "https://cluster.example.com"}},
+			want: ClusterSpec{KubernetesAPIEndpoints: KubernetesAPIEndpoints{ServerEndpoints: []ServerAddressByClientCIDR{
+				{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com:443"},
+				{ClientCIDR: "::/0", ServerAddress: "cluster.example.com:443"},
+			}}},
+		},
+		{
+			name:      "single endpoint with CIDR",
+			endpoints: []ServerAddressByClientCIDR{{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1:6443"}},
+			want: ClusterSpec{KubernetesAPIEndpoints: KubernetesAPIEndpoints{ServerEndpoints: []ServerAddressByClientCIDR{
+				{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1:6443"},
+			}}},
+		},
+		{
+			name: "several endpoints",
+			endpoints: []ServerAddressByClientCIDR{
+				{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1"},
+				{ServerAddress: "cluster.example.com"},
+			},
+			want: ClusterSpec{KubernetesAPIEndpoints: KubernetesAPIEndpoints{ServerEndpoints: []ServerAddressByClientCIDR{
+				{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1:443"},
+				{ServerAddress: "cluster.example.com:443"},
+			}}},
+		},
+		{
+			name:      "legacy client CIDR",
+			endpoints: []ServerAddressByClientCIDR{{ClientCIDR: "0.0.0.0", ServerAddress: "127.0.0.1"}},
+			want: ClusterSpec{KubernetesAPIEndpoints: KubernetesAPIEndpoints{ServerEndpoints: []ServerAddressByClientCIDR{
+				{ClientCIDR: "0.0.0.0/0", ServerAddress: "127.0.0.1:443"},
+			}}},
+		},
+		{
+			name: "references",
+			authInfo: AuthInfo{
+				User:       &ObjectReference{Kind: "ConfigMap", Name: "user"},
+				Controller: &ObjectReference{Kind: "Secret", Name: "controller", Namespace: "kube-system"},
+			},
+			want: ClusterSpec{AuthInfo: AuthInfo{
+				User:       &ObjectReference{Kind: "ConfigMap", Name: "user", Namespace: "registry"},
+				Controller: &ObjectReference{Kind: "Secret", Name: "controller", Namespace: "kube-system"},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "registry"}}
+			cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = tc.endpoints
+			cluster.Spec.AuthInfo = tc.authInfo
+
+			SetDefaults_Cluster(cluster)
+			if !reflect.DeepEqual(cluster.Spec, tc.want) {
+				t.Errorf("Expected %#v, got %#v", tc.want, cluster.Spec)
+			}
+		})
+	}
+}
+
+func TestSchemeDefaulting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cluster := &Cluster{}
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []ServerAddressByClientCIDR{{ServerAddress: "cluster.example.com"}}
+	scheme.Default(cluster)
+	if len(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints) != 2 {
+		t.Errorf("Expected the scheme to default the cluster, got %#v", cluster.Spec)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helper contains helper functions for working with the v1alpha1
+// clusterregistry types.
+package helper
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// GetClusterCondition returns the condition of type conditionType in status,
+// or nil if there is no such condition. The returned pointer refers to the
+// condition stored in status, so modifying it modifies status.
+func GetClusterCondition(status *v1alpha1.ClusterStatus, conditionType v1alpha1.ClusterConditionType) *v1alpha1.ClusterCondition {
+	if status == nil {
+		return nil
+	}
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == conditionType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsClusterConditionTrue returns true if status contains a condition of type
+// conditionType whose status is True.
+func IsClusterConditionTrue(status *v1alpha1.ClusterStatus, conditionType v1alpha1.ClusterConditionType) bool {
+	condition := GetClusterCondition(status, conditionType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// SetClusterCondition adds condition to status, or updates the existing
+// condition of the same type, and returns true if status was modified.
+//
+// The LastHeartbeatTime of condition is the time at which it was observed; if
+// it is zero, the current time is used. When an existing condition is updated:
+//
+//   - LastTransitionTime is only set to the observation time if the status of
+//     the condition changed.
+//   - LastHeartbeatTime is only set to the observation time if the status,
+//     reason or message of the condition changed, or if the previous heartbeat
+//     is at least heartbeatGranularity old. This allows reporters to observe a
+//     condition frequently without writing every observation to the API
+//     server. A heartbeatGranularity of zero records every observation.
+//
+// The LastTransitionTime of condition is ignored. A nil status cannot hold
+// the condition, so it is left alone and false is returned.
+func SetClusterCondition(status *v1alpha1.ClusterStatus, condition v1alpha1.ClusterCondition, heartbeatGranularity time.Duration) bool {
+	if status == nil {
+		return false
+	}
+	now := condition.LastHeartbeatTime
+	if now.IsZero() {
+		now = metav1.Now()
+	}
+
+	existing := GetClusterCondition(status, condition.Type)
+	if existing == nil {
+		condition.LastHeartbeatTime = now
+		condition.LastTransitionTime = now
+		status.Conditions = append(status.Conditions, condition)
+		return true
+	}
+
+	changed := false
+	if existing.Status != condition.Status {
+		existing.Status = condition.Status
+		existing.LastTransitionTime = now
+		changed = true
+	}
+	if existing.Reason != condition.Reason || existing.Message != condition.Message {
+		existing.Reason = condition.Reason
+		existing.Message = condition.Message
+		changed = true
+	}
+	if changed || now.Sub(existing.LastHeartbeatTime.Time) >= heartbeatGranularity {
+		existing.LastHeartbeatTime = now
+		changed = true
+	}
+	return changed
+}
+
+// RemoveClusterCondition removes the condition of type conditionType from
+// status, and returns true if status was modified.
+func RemoveClusterCondition(status *v1alpha1.ClusterStatus, conditionType v1alpha1.ClusterConditionType) bool {
+	if GetClusterCondition(status, conditionType) == nil {
+		return false
+	}
+	var conditions []v1alpha1.ClusterCondition
+	for _, condition := range status.Conditions {
+		if condition.Type != conditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	status.Conditions = conditions
+	return true
+}
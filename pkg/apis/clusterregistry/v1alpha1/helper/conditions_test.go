@@ -0,0 +1,146 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+var (
+	t0 = metav1.NewTime(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	t1 = metav1.NewTime(t0.Add(30 * time.Second))
+	t2 = metav1.NewTime(t0.Add(2 * time.Minute))
+)
+
+func okCondition(status corev1.ConditionStatus, reason string, heartbeat, transition metav1.Time) v1alpha1.ClusterCondition {
+	return v1alpha1.ClusterCondition{
+		Type:               v1alpha1.ClusterOK,
+		Status:             status,
+		Reason:             reason,
+		LastHeartbeatTime:  heartbeat,
+		LastTransitionTime: transition,
+	}
+}
+
+func TestSetClusterCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    []v1alpha1.ClusterCondition
+		condition   v1alpha1.ClusterCondition
+		wantChanged bool
+		want        v1alpha1.ClusterCondition
+	}{
+		{
+			name:        "new condition",
+			condition:   okCondition(corev1.ConditionTrue, "Healthy", t1, metav1.Time{}),
+			wantChanged: true,
+			want:        okCondition(corev1.ConditionTrue, "Healthy", t1, t1),
+		},
+		{
+			name:        "unchanged within granularity",
+			existing:    []v1alpha1.ClusterCondition{okCondition(corev1.ConditionTrue, "Healthy", t0, t0)},
+			condition:   okCondition(corev1.ConditionTrue, "Healthy", t1, metav1.Time{}),
+			wantChanged: false,
+			want:        okCondition(corev1.ConditionTrue, "Healthy", t0, t0),
+		},
+		{
+			name:        "unchanged after granularity",
+			existing:    []v1alpha1.ClusterCondition{okCondition(corev1.ConditionTrue, "Healthy", t0, t0)},
+			condition:   okCondition(corev1.ConditionTrue, "Healthy", t2, metav1.Time{}),
+			wantChanged: true,
+			want:        okCondition(corev1.ConditionTrue, "Healthy", t2, t0),
+		},
+		{
+			name:        "reason changed",
+			existing:    []v1alpha1.ClusterCondition{okCondition(corev1.ConditionFalse, "Unhealthy", t0, t0)},
+			condition:   okCondition(corev1.ConditionFalse, "Unreachable", t1, metav1.Time{}),
+			wantChanged: true,
+			want:        okCondition(corev1.ConditionFalse, "Unreachable", t1, t0),
+		},
+		{
+			name:        "status changed",
+			existing:    []v1alpha1.ClusterCondition{okCondition(corev1.ConditionFalse, "Healthy", t0, t0)},
+			condition:   okCondition(corev1.ConditionTrue, "Healthy", t1, t0),
+			wantChanged: true,
+			want:        okCondition(corev1.ConditionTrue, "Healthy", t1, t1),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status := &v1alpha1.ClusterStatus{Conditions: tc.existing}
+			if changed := SetClusterCondition(status, tc.condition, time.Minute); changed != tc.wantChanged {
+				t.Errorf("Expected changed to be %v, got %v", tc.wantChanged, changed)
+			}
+			if len(status.Conditions) != 1 {
+				t.Fatalf("Expected exactly one condition, got %v", status.Conditions)
+			}
+			got := status.Conditions[0]
+			if got.Status != tc.want.Status || got.Reason != tc.want.Reason {
+				t.Errorf("Expected %s/%s, got %s/%s", tc.want.Status, tc.want.Reason, got.Status, got.Reason)
+			}
+			if !got.LastHeartbeatTime.Equal(&tc.want.LastHeartbeatTime) {
+				t.Errorf("Expected LastHeartbeatTime %v, got %v", tc.want.LastHeartbeatTime, got.LastHeartbeatTime)
+			}
+			if !got.LastTransitionTime.Equal(&tc.want.LastTransitionTime) {
+				t.Errorf("Expected LastTransitionTime %v, got %v", tc.want.LastTransitionTime, got.LastTransitionTime)
+			}
+		})
+	}
+}
+
+func TestGetAndRemoveClusterCondition(t *testing.T) {
+	status := &v1alpha1.ClusterStatus{Conditions: []v1alpha1.ClusterCondition{
+		okCondition(corev1.ConditionTrue, "Healthy", t0, t0),
+		{Type: "Other", Status: corev1.ConditionFalse},
+	}}
+
+	if !IsClusterConditionTrue(status, v1alpha1.ClusterOK) {
+		t.Errorf("Expected %q to be true", v1alpha1.ClusterOK)
+	}
+	if IsClusterConditionTrue(status, "Other") {
+		t.Errorf("Expected %q not to be true", "Other")
+	}
+	if condition := GetClusterCondition(status, "Missing"); condition != nil {
+		t.Errorf("Expected no condition, got %v", condition)
+	}
+
+	if !RemoveClusterCondition(status, v1alpha1.ClusterOK) {
+		t.Errorf("Expected %q to be removed", v1alpha1.ClusterOK)
+	}
+	if RemoveClusterCondition(status, v1alpha1.ClusterOK) {
+		t.Errorf("Expected %q to already be removed", v1alpha1.ClusterOK)
+	}
+	if len(status.Conditions) != 1 || status.Conditions[0].Type != "Other" {
+		t.Errorf("Expected only the %q condition to remain, got %v", "Other", status.Conditions)
+	}
+	if IsClusterConditionTrue(nil, v1alpha1.ClusterOK) {
+		t.Errorf("Expected a nil status to have no true conditions")
+	}
+	if SetClusterCondition(nil, okCondition(corev1.ConditionTrue, "Healthy", t0, t0), 0) {
+		t.Errorf("Expected a nil status not to be modified")
+	}
+	if RemoveClusterCondition(nil, v1alpha1.ClusterOK) {
+		t.Errorf("Expected a nil status not to be modified")
+	}
+}
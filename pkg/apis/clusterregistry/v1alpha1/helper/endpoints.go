@@ -0,0 +1,196 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// ErrNoServerEndpoints is returned when a cluster has no server endpoints.
+var ErrNoServerEndpoints = errors.New("cluster has no server endpoints")
+
+// NoMatchingServerAddressError is returned when none of the server endpoints
+// of a cluster match the client IPs.
+type NoMatchingServerAddressError struct {
+	// Cluster is the namespace/name of the cluster.
+	Cluster string
+	// ClientIPs are the client IPs for which a server address was requested.
+	ClientIPs []net.IP
+	// InvalidCIDRs are the ClientCIDRs of the cluster that could not be
+	// parsed, and were therefore ignored.
+	InvalidCIDRs []string
+}
+
+func (e *NoMatchingServerAddressError) Error() string {
+	ips := make([]string, len(e.ClientIPs))
+	for i, ip := range e.ClientIPs {
+		ips[i] = ip.String()
+	}
+	msg := fmt.Sprintf("no server endpoint of cluster %s matches client IPs [%s]", e.Cluster, strings.Join(ips, ", "))
+	if len(e.InvalidCIDRs) > 0 {
+		msg += fmt.Sprintf(" (ignored invalid client CIDRs [%s])", strings.Join(e.InvalidCIDRs, ", "))
+	}
+	return msg
+}
+
+// fallbackCIDR is the ClientCIDR that matches every client, including IPv6
+// clients that do not match any IPv6 CIDR.
+const fallbackCIDR = "0.0.0.0/0"
+
+// ServerAddressForClientIP returns the server address of cluster that a client
+// with IP clientIP should use. See ServerAddressForClientIPs.
+func ServerAddressForClientIP(cluster *v1alpha1.Cluster, clientIP net.IP) (string, error) {
+	return ServerAddressForClientIPs(cluster, []net.IP{clientIP})
+}
+
+// ServerAddressForClient returns the server address of cluster that a client
+// with IP clientIP should use, or that a client running on the local host
+// should use if clientIP is nil.
+func ServerAddressForClient(cluster *v1alpha1.Cluster, clientIP net.IP) (string, error) {
+	if clientIP == nil {
+		return ServerAddressForLocalHost(cluster)
+	}
+	return ServerAddressForClientIP(cluster, clientIP)
+}
+
+// ServerAddressForLocalHost returns the server address of cluster that a
+// client running on the local host should use, based on the addresses of the
+// local network interfaces. See ServerAddressForClientIPs.
+func ServerAddressForLocalHost(cluster *v1alpha1.Cluster) (string, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list local addresses")
+	}
+	var ips []net.IP
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ServerAddressForClientIPs(cluster, ips)
+}
+
+// ServerAddressForClientIPs returns the server address of cluster that a
+// client with the given IPs should use.
+//
+// The server endpoint whose ClientCIDR is the longest prefix match for any of
+// the client IPs is chosen; IPv4 CIDRs only match IPv4 clients and IPv6 CIDRs
+// only match IPv6 clients, except for 0.0.0.0/0, which matches every client
+// but is only used for IPv6 clients if no IPv6 CIDR matches. An empty
+// ClientCIDR is treated as 0.0.0.0/0, and so is the legacy ClientCIDR 0.0.0.0;
+// see ParseClientCIDR. If several endpoints match equally well, the first one
+// is chosen.
+//
+// ErrNoServerEndpoints is returned if the cluster has no server endpoints,
+// and a *NoMatchingServerAddressError if none of them match.
+func ServerAddressForClientIPs(cluster *v1alpha1.Cluster, clientIPs []net.IP) (string, error) {
+	endpoints := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints
+	if len(endpoints) == 0 {
+		return "", ErrNoServerEndpoints
+	}
+
+	best, bestScore := -1, -1
+	var invalidCIDRs []string
+	for i, endpoint := range endpoints {
+		ipNet, err := ParseClientCIDR(endpoint.ClientCIDR)
+		if err != nil {
+			invalidCIDRs = append(invalidCIDRs, endpoint.ClientCIDR)
+			continue
+		}
+		for _, ip := range clientIPs {
+			if score := matchScore(ipNet, ip); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+	}
+
+	if best < 0 {
+		return "", &NoMatchingServerAddressError{
+			Cluster:      cluster.Namespace + "/" + cluster.Name,
+			ClientIPs:    clientIPs,
+			InvalidCIDRs: invalidCIDRs,
+		}
+	}
+	return endpoints[best].ServerAddress, nil
+}
+
+// ParseClientCIDR parses the ClientCIDR of a server endpoint. An empty
+// ClientCIDR is 0.0.0.0/0. Bare IP addresses, which older clusters were
+// registered with, are also accepted: the unspecified addresses 0.0.0.0 and
+// ::, which the original examples used to match every client, are treated as
+// 0.0.0.0/0 and ::/0, and any other address as a CIDR containing only that
+// address.
+func ParseClientCIDR(cidr string) (*net.IPNet, error) {
+	if cidr == "" {
+		cidr = fallbackCIDR
+	}
+	if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+		return ipNet, nil
+	}
+	ip := net.ParseIP(cidr)
+	if ip == nil {
+		return nil, errors.Errorf("invalid client CIDR %q", cidr)
+	}
+	bits := net.IPv6len * 8
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, net.IPv4len*8
+	}
+	ones := bits
+	if ip.IsUnspecified() {
+		ones = 0
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(ones, bits)}, nil
+}
+
+// matchScore returns how well ipNet matches ip: one more than the prefix
+// length of ipNet if it contains ip, 0 if ipNet is 0.0.0.0/0 and ip is an IPv6
+// address, and -1 otherwise. The 0.0.0.0/0 fallback thus scores lower than
+// ::/0 for IPv6 addresses.
+func matchScore(ipNet *net.IPNet, ip net.IP) int {
+	ones, bits := ipNet.Mask.Size()
+	if ipNet.Contains(ip) {
+		return ones + 1
+	}
+	if ones == 0 && bits == net.IPv4len*8 && ip.To4() == nil && ip.To16() != nil {
+		return 0
+	}
+	return -1
+}
+
+// ServerURL returns the base URL of the Kubernetes API server at address,
+// which is a ServerAddress of a cluster. Addresses without a scheme are
+// assumed to be served over HTTPS.
+func ServerURL(address string) (*url.URL, error) {
+	if !strings.Contains(address, "://") {
+		address = "https://" + address
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid server address %q", address)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("invalid server address %q: missing host", address)
+	}
+	return u, nil
+}
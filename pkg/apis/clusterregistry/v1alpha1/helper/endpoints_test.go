@@ -0,0 +1,136 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"net"
+	"testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+func clusterWithEndpoints(endpoints ...v1alpha1.ServerAddressByClientCIDR) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{}
+	cluster.Namespace, cluster.Name = "default", "cluster"
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = endpoints
+	return cluster
+}
+
+func TestServerAddressForClientIP(t *testing.T) {
+	cluster := clusterWithEndpoints(
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: "public.example.com"},
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1"},
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.1.0.0/16", ServerAddress: "10.1.0.1"},
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "fd00::/8", ServerAddress: "[fd00::1]:6443"},
+	)
+	ipv6Cluster := clusterWithEndpoints(
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: "public.example.com"},
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "::/0", ServerAddress: "public6.example.com"},
+	)
+
+	tests := []struct {
+		name     string
+		cluster  *v1alpha1.Cluster
+		clientIP string
+		want     string
+	}{
+		{"fallback", cluster, "192.168.0.1", "public.example.com"},
+		{"shorter prefix", cluster, "10.2.0.1", "10.0.0.1"},
+		{"longest prefix", cluster, "10.1.2.3", "10.1.0.1"},
+		{"IPv4-mapped IPv6", cluster, "::ffff:10.1.2.3", "10.1.0.1"},
+		{"IPv6", cluster, "fd00::1234", "[fd00::1]:6443"},
+		{"IPv6 fallback to 0.0.0.0/0", cluster, "2001:db8::1", "public.example.com"},
+		{"IPv6 prefers ::/0", ipv6Cluster, "2001:db8::1", "public6.example.com"},
+		{"IPv4 ignores ::/0", ipv6Cluster, "192.168.0.1", "public.example.com"},
+		{"empty CIDR", clusterWithEndpoints(v1alpha1.ServerAddressByClientCIDR{ServerAddress: "any"}), "2001:db8::1", "any"},
+		{"legacy 0.0.0.0", clusterWithEndpoints(v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0", ServerAddress: "any"}), "192.168.0.1", "any"},
+		{"legacy 0.0.0.0 for IPv6", clusterWithEndpoints(v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0", ServerAddress: "any"}), "2001:db8::1", "any"},
+		{"legacy ::", clusterWithEndpoints(v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "::", ServerAddress: "any6"}), "2001:db8::1", "any6"},
+		{"bare IP", clusterWithEndpoints(
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: "public.example.com"},
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.0.0.1", ServerAddress: "10.0.0.1"},
+		), "10.0.0.1", "10.0.0.1"},
+		{"bare IP only matches itself", clusterWithEndpoints(
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: "public.example.com"},
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.0.0.1", ServerAddress: "10.0.0.1"},
+		), "10.0.0.2", "public.example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ServerAddressForClientIP(tc.cluster, net.ParseIP(tc.clientIP))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("Expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestServerAddressForClientIPErrors(t *testing.T) {
+	if _, err := ServerAddressForClientIP(clusterWithEndpoints(), net.ParseIP("10.0.0.1")); err != ErrNoServerEndpoints {
+		t.Errorf("Expected ErrNoServerEndpoints, got %v", err)
+	}
+
+	cluster := clusterWithEndpoints(
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1"},
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "public", ServerAddress: "public.example.com"},
+	)
+	_, err := ServerAddressForClientIP(cluster, net.ParseIP("192.168.0.1"))
+	noMatch, ok := err.(*NoMatchingServerAddressError)
+	if !ok {
+		t.Fatalf("Expected a *NoMatchingServerAddressError, got %v", err)
+	}
+	if noMatch.Cluster != "default/cluster" {
+		t.Errorf("Expected cluster %q, got %q", "default/cluster", noMatch.Cluster)
+	}
+	if len(noMatch.InvalidCIDRs) != 1 || noMatch.InvalidCIDRs[0] != "public" {
+		t.Errorf("Expected invalid CIDRs [public], got %v", noMatch.InvalidCIDRs)
+	}
+}
+
+func TestServerURL(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+		wantErr bool
+	}{
+		{address: "1.2.3.4", want: "https://1.2.3.4"},
+		{address: "1.2.3.4:6443", want: "https://1.2.3.4:6443"},
+		{address: "cluster.example.com", want: "https://cluster.example.com"},
+		{address: "http://cluster.example.com:8080", want: "http://cluster.example.com:8080"},
+		{address: "", wantErr: true},
+		{address: "https://", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		u, err := ServerURL(tc.address)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", tc.address, u)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tc.address, err)
+		} else if u.String() != tc.want {
+			t.Errorf("%q: expected %q, got %q", tc.address, tc.want, u.String())
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// ReadySubConditionTypes are the condition types from which the ClusterReady
+// condition is aggregated, in the order in which they are reported.
+var ReadySubConditionTypes = []v1alpha1.ClusterConditionType{
+	v1alpha1.ClusterReachable,
+	v1alpha1.ClusterAuthenticated,
+	v1alpha1.ClusterCertificateValid,
+}
+
+// Reasons used in the ClusterReady condition computed by
+// AggregateReadyCondition.
+const (
+	// ReasonSubConditionsTrue means that all sub-conditions are True.
+	ReasonSubConditionsTrue = "SubConditionsTrue"
+
+	// ReasonSubConditionFalse means that at least one sub-condition is False.
+	ReasonSubConditionFalse = "SubConditionFalse"
+
+	// ReasonSubConditionUnknown means that no sub-condition is False, but at
+	// least one is Unknown or not reported.
+	ReasonSubConditionUnknown = "SubConditionUnknown"
+)
+
+// AggregateReadyCondition computes the ClusterReady condition from the
+// ReadySubConditionTypes conditions in status, following the rule documented
+// on v1alpha1.ClusterReady. The returned condition has no timestamps; use
+// SetClusterCondition to record it in status.
+func AggregateReadyCondition(status *v1alpha1.ClusterStatus) v1alpha1.ClusterCondition {
+	var falseConditions, unknownConditions []string
+	for _, conditionType := range ReadySubConditionTypes {
+		condition := GetClusterCondition(status, conditionType)
+		switch {
+		case condition == nil:
+			unknownConditions = append(unknownConditions, fmt.Sprintf("%s is not reported", conditionType))
+		case condition.Status == corev1.ConditionTrue:
+		case condition.Status == corev1.ConditionFalse:
+			falseConditions = append(falseConditions, describeCondition(condition))
+		default:
+			unknownConditions = append(unknownConditions, describeCondition(condition))
+		}
+	}
+
+	ready := v1alpha1.ClusterCondition{Type: v1alpha1.ClusterReady}
+	switch {
+	case len(falseConditions) > 0:
+		ready.Status = corev1.ConditionFalse
+		ready.Reason = ReasonSubConditionFalse
+		ready.Message = strings.Join(falseConditions, "; ")
+	case len(unknownConditions) > 0:
+		ready.Status = corev1.ConditionUnknown
+		ready.Reason = ReasonSubConditionUnknown
+		ready.Message = strings.Join(unknownConditions, "; ")
+	default:
+		ready.Status = corev1.ConditionTrue
+		ready.Reason = ReasonSubConditionsTrue
+	}
+	return ready
+}
+
+// describeCondition returns a short human-readable description of condition
+// for use in the message of an aggregate condition.
+func describeCondition(condition *v1alpha1.ClusterCondition) string {
+	description := fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+	if condition.Reason != "" {
+		description += fmt.Sprintf(" (%s)", condition.Reason)
+	}
+	if condition.Message != "" {
+		description += ": " + condition.Message
+	}
+	return description
+}
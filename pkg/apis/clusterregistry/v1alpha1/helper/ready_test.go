@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+func TestAggregateReadyCondition(t *testing.T) {
+	condition := func(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) v1alpha1.ClusterCondition {
+		return v1alpha1.ClusterCondition{Type: conditionType, Status: status}
+	}
+
+	tests := []struct {
+		name       string
+		conditions []v1alpha1.ClusterCondition
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "all true",
+			conditions: []v1alpha1.ClusterCondition{
+				condition(v1alpha1.ClusterReachable, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterAuthenticated, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterCertificateValid, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterDegraded, corev1.ConditionTrue),
+			},
+			wantStatus: corev1.ConditionTrue,
+			wantReason: ReasonSubConditionsTrue,
+		},
+		{
+			name: "one false",
+			conditions: []v1alpha1.ClusterCondition{
+				condition(v1alpha1.ClusterReachable, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterAuthenticated, corev1.ConditionFalse),
+				condition(v1alpha1.ClusterCertificateValid, corev1.ConditionUnknown),
+			},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: ReasonSubConditionFalse,
+		},
+		{
+			name: "one unknown",
+			conditions: []v1alpha1.ClusterCondition{
+				condition(v1alpha1.ClusterReachable, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterAuthenticated, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterCertificateValid, corev1.ConditionUnknown),
+			},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: ReasonSubConditionUnknown,
+		},
+		{
+			name: "one missing",
+			conditions: []v1alpha1.ClusterCondition{
+				condition(v1alpha1.ClusterReachable, corev1.ConditionTrue),
+				condition(v1alpha1.ClusterCertificateValid, corev1.ConditionTrue),
+			},
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: ReasonSubConditionUnknown,
+		},
+		{
+			name:       "none",
+			wantStatus: corev1.ConditionUnknown,
+			wantReason: ReasonSubConditionUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ready := AggregateReadyCondition(&v1alpha1.ClusterStatus{Conditions: tc.conditions})
+			if ready.Type != v1alpha1.ClusterReady {
+				t.Errorf("Expected type %q, got %q", v1alpha1.ClusterReady, ready.Type)
+			}
+			if ready.Status != tc.wantStatus || ready.Reason != tc.wantReason {
+				t.Errorf("Expected %s/%s, got %s/%s (%s)", tc.wantStatus, tc.wantReason, ready.Status, ready.Reason, ready.Message)
+			}
+		})
+	}
+}
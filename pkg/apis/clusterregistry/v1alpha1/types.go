@@ -25,8 +25,12 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // Cluster contains information about a cluster in a cluster registry.
-// +k8s:openapi-gen=x-kubernetes-print-columns:custom-columns=NAME:.metadata.name,CIDR:.spec.kubernetesApiEndpoints.serverEndpoints[].clientCIDR,SERVER:.spec.kubernetesApiEndpoints.serverEndpoints[].serverAddress,CREATION TIME:.metadata.creationTimestamp
-// +resource:path=clusters
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusters,scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.kubernetesApiEndpoints.serverEndpoints[0].serverAddress`,description="The address of the first API server endpoint of the cluster."
+// +kubebuilder:printcolumn:name="OK",type=string,JSONPath=`.status.conditions[?(@.type=="OK")].status`,description="The status of the OK condition of the cluster."
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type Cluster struct {
 	metav1.TypeMeta `json:",inline"`
 	// Standard object's metadata.
@@ -44,6 +48,21 @@ type Cluster struct {
 	Status ClusterStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterList is a list of Clusters.
+// +kubebuilder:object:root=true
+type ClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Items is the list of Clusters.
+	Items []Cluster `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
 // ClusterSpec contains the specification of a cluster.
 type ClusterSpec struct {
 	// KubernetesAPIEndpoints represents the endpoints of the API server for this
@@ -65,7 +84,35 @@ type ClusterStatus struct {
 	// Conditions contains the different condition statuses for this cluster.
 	Conditions []ClusterCondition `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions"`
 
-	// TODO https://github.com/kubernetes/cluster-registry/issues/28
+	// ServerVersion is the git version of the cluster's Kubernetes API server,
+	// as reported by its /version endpoint, e.g. "v1.13.2".
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty" protobuf:"bytes,2,opt,name=serverVersion"`
+
+	// Platform is the platform on which the cluster's Kubernetes API server is
+	// running, as reported by its /version endpoint, e.g. "linux/amd64".
+	// +optional
+	Platform string `json:"platform,omitempty" protobuf:"bytes,3,opt,name=platform"`
+
+	// NodeCount is the number of nodes registered in the cluster. It is unset if
+	// the number of nodes is not known.
+	// +optional
+	NodeCount *int32 `json:"nodeCount,omitempty" protobuf:"varint,4,opt,name=nodeCount"`
+
+	// Capacity is the sum of the capacity of all nodes in the cluster.
+	// More info: https://kubernetes.io/docs/concepts/architecture/nodes/#capacity
+	// +optional
+	Capacity v1.ResourceList `json:"capacity,omitempty" protobuf:"bytes,5,rep,name=capacity,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName"`
+
+	// Allocatable is the sum of the resources of all nodes in the cluster that
+	// are available for scheduling.
+	// +optional
+	Allocatable v1.ResourceList `json:"allocatable,omitempty" protobuf:"bytes,6,rep,name=allocatable,casttype=k8s.io/api/core/v1.ResourceList,castkey=k8s.io/api/core/v1.ResourceName"`
+
+	// LastObservedTime is the last time the fields in this status other than
+	// Conditions were observed by a controller reporting on the cluster.
+	// +optional
+	LastObservedTime metav1.Time `json:"lastObservedTime,omitempty" protobuf:"bytes,7,opt,name=lastObservedTime"`
 }
 
 // KubernetesAPIEndpoints represents the endpoints for one and only one
@@ -135,12 +182,53 @@ type ClusterConditionType string
 const (
 	// ClusterOK means that the cluster is "OK".
 	//
-	// Since the cluster registry does not have a standard status controller, the
-	// meaning of this condition is defined by the environment in which the
-	// cluster is running. It is expected to mean that the cluster is reachable by
-	// a controller that is reporting on its status, and that the cluster is ready
-	// to have workloads scheduled.
+	// It is expected to mean that the cluster is reachable by a controller that
+	// is reporting on its status, and that the cluster is ready to have
+	// workloads scheduled. The cluster status controller in
+	// k8s.io/cluster-registry/pkg/controller/clusterstatus sets this condition
+	// based on the /healthz and /readyz endpoints of the cluster's API server;
+	// environments that do not run it may define its meaning differently.
+	//
+	// New reporters should prefer the more specific condition types below.
 	ClusterOK ClusterConditionType = "OK"
+
+	// ClusterReachable means that the cluster's Kubernetes API server can be
+	// reached over the network by a controller that is reporting on its status,
+	// and that it responds to HTTP requests.
+	ClusterReachable ClusterConditionType = "Reachable"
+
+	// ClusterAuthenticated means that a controller that is reporting on the
+	// cluster's status is able to authenticate to the cluster's Kubernetes API
+	// server using the credentials referenced by AuthInfo.
+	ClusterAuthenticated ClusterConditionType = "Authenticated"
+
+	// ClusterCertificateValid means that the serving certificate of the
+	// cluster's Kubernetes API server is valid and trusted by the CABundle in
+	// KubernetesAPIEndpoints.
+	ClusterCertificateValid ClusterConditionType = "CertificateValid"
+
+	// ClusterReady means that the cluster is ready to have workloads scheduled.
+	//
+	// ClusterReady is an aggregate condition computed from the
+	// ClusterReachable, ClusterAuthenticated and ClusterCertificateValid
+	// conditions:
+	//
+	//   - it is True if all of them are True;
+	//   - otherwise it is False if any of them is False;
+	//   - otherwise, i.e. if any of them is Unknown or not reported, it is
+	//     Unknown.
+	//
+	// ClusterDegraded does not affect ClusterReady. The helper package
+	// k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper
+	// implements this rule, and the cluster status controller in
+	// k8s.io/cluster-registry/pkg/controller/clusterstatus maintains
+	// ClusterReady and its sub-conditions.
+	ClusterReady ClusterConditionType = "Ready"
+
+	// ClusterDegraded means that the cluster is Ready but impaired, e.g. some
+	// of its API server endpoints or nodes are unavailable. Schedulers may
+	// prefer clusters that are Ready and not Degraded.
+	ClusterDegraded ClusterConditionType = "Degraded"
 )
 
 // ClusterCondition contains condition information for a cluster.
@@ -0,0 +1,161 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation validates v1alpha1 Clusters. It is used by the
+// validating admission webhook, and can be used by clients to check a Cluster
+// before sending it to the cluster registry.
+package validation
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+)
+
+var (
+	// SupportedUserReferenceKinds are the kinds of object that AuthInfo.User
+	// may point to. The user information is public, so it may be kept in a
+	// ConfigMap. A reference with an empty Kind refers to a Secret.
+	SupportedUserReferenceKinds = sets.NewString("Secret", "ConfigMap")
+
+	// SupportedControllerReferenceKinds are the kinds of object that
+	// AuthInfo.Controller may point to. Only Secrets can be read by the
+	// clusterclient package. A reference with an empty Kind refers to a
+	// Secret.
+	SupportedControllerReferenceKinds = sets.NewString("Secret")
+)
+
+// ValidateCluster validates cluster and returns the errors found, each with
+// the path of the offending field.
+func ValidateCluster(cluster *v1alpha1.Cluster) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMeta(&cluster.ObjectMeta, true, apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateClusterSpec(&cluster.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateClusterUpdate validates an update of oldCluster to cluster. The spec
+// is only validated if the update changes it, so that Clusters stored before
+// validation was enforced can still be relabeled or have their status
+// updated.
+func ValidateClusterUpdate(cluster, oldCluster *v1alpha1.Cluster) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMetaUpdate(&cluster.ObjectMeta, &oldCluster.ObjectMeta, field.NewPath("metadata"))
+	if !apiequality.Semantic.DeepEqual(cluster.Spec, oldCluster.Spec) {
+		allErrs = append(allErrs, ValidateClusterSpec(&cluster.Spec, field.NewPath("spec"))...)
+	}
+	return allErrs
+}
+
+// ValidateClusterSpec validates spec, whose path is fldPath.
+func ValidateClusterSpec(spec *v1alpha1.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	endpointsPath := fldPath.Child("kubernetesApiEndpoints")
+	for i, endpoint := range spec.KubernetesAPIEndpoints.ServerEndpoints {
+		allErrs = append(allErrs, validateServerEndpoint(endpoint, endpointsPath.Child("serverEndpoints").Index(i))...)
+	}
+	if caBundle := spec.KubernetesAPIEndpoints.CABundle; len(caBundle) > 0 {
+		allErrs = append(allErrs, validateCABundle(caBundle, endpointsPath.Child("caBundle"))...)
+	}
+
+	authInfoPath := fldPath.Child("authInfo")
+	if spec.AuthInfo.User != nil {
+		allErrs = append(allErrs, validateObjectReference(spec.AuthInfo.User, SupportedUserReferenceKinds, authInfoPath.Child("user"))...)
+	}
+	if spec.AuthInfo.Controller != nil {
+		allErrs = append(allErrs, validateObjectReference(spec.AuthInfo.Controller, SupportedControllerReferenceKinds, authInfoPath.Child("controller"))...)
+	}
+
+	return allErrs
+}
+
+func validateServerEndpoint(endpoint v1alpha1.ServerAddressByClientCIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// An empty ClientCIDR matches all clients, and bare IP addresses such as
+	// the legacy 0.0.0.0 are accepted as the server endpoints do.
+	if _, err := helper.ParseClientCIDR(endpoint.ClientCIDR); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clientCIDR"), endpoint.ClientCIDR, "must be a CIDR, e.g. 10.0.0.0/8 or ::/0, or an IP address"))
+	}
+
+	if endpoint.ServerAddress == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serverAddress"), ""))
+	} else if _, err := helper.ServerURL(endpoint.ServerAddress); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serverAddress"), endpoint.ServerAddress, err.Error()))
+	}
+
+	return allErrs
+}
+
+// validateCABundle checks that caBundle consists only of PEM-encoded
+// certificates, and contains at least one.
+func validateCABundle(caBundle []byte, fldPath *field.Path) field.ErrorList {
+	// The bundle itself is not included in the errors, as it would make them
+	// unreadable.
+	value := fmt.Sprintf("<%d bytes>", len(caBundle))
+
+	count := 0
+	for rest := caBundle; ; count++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("PEM block %d is a %q, not a CERTIFICATE", count, block.Type))}
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("certificate %d could not be parsed: %v", count, err))}
+		}
+	}
+	if count == 0 {
+		return field.ErrorList{field.Invalid(fldPath, value, "must contain at least one PEM-encoded certificate")}
+	}
+	return nil
+}
+
+// validateObjectReference validates ref, which may point to an object of one
+// of kinds.
+func validateObjectReference(ref *v1alpha1.ObjectReference, kinds sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ref.Kind != "" && !kinds.Has(ref.Kind) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("kind"), ref.Kind, kinds.List()))
+	}
+
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range apimachineryvalidation.NameIsDNSSubdomain(ref.Name, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), ref.Name, msg))
+		}
+	}
+
+	if ref.Namespace != "" {
+		for _, msg := range apimachineryvalidation.ValidateNamespaceName(ref.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), ref.Namespace, msg))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// The functions in this file convert Clusters between v1alpha1 and v1beta1.
+// The two versions currently have the same fields, but each type is converted
+// by its own function so that fields can be added, renamed or restructured in
+// v1beta1 by changing only the affected functions. Conversions must be
+// lossless in both directions: a v1beta1 field that has no v1alpha1
+// counterpart has to be preserved, e.g. in an annotation, when converting to
+// v1alpha1 and restored when converting back.
+//
+// The conversions deep copy their input, so that in and out do not share
+// memory.
+
+// Convert_v1alpha1_Cluster_To_v1beta1_Cluster converts a v1alpha1 Cluster to
+// a v1beta1 Cluster. The TypeMeta of out is set to v1beta1.
+func Convert_v1alpha1_Cluster_To_v1beta1_Cluster(in *v1alpha1.Cluster, out *Cluster) error {
+	out.TypeMeta = in.TypeMeta
+	out.APIVersion = SchemeGroupVersion.String()
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if err := Convert_v1alpha1_ClusterSpec_To_v1beta1_ClusterSpec(&in.Spec, &out.Spec); err != nil {
+		return err
+	}
+	return Convert_v1alpha1_ClusterStatus_To_v1beta1_ClusterStatus(&in.Status, &out.Status)
+}
+
+// Convert_v1beta1_Cluster_To_v1alpha1_Cluster converts a v1beta1 Cluster to
+// a v1alpha1 Cluster. The TypeMeta of out is set to v1alpha1.
+func Convert_v1beta1_Cluster_To_v1alpha1_Cluster(in *Cluster, out *v1alpha1.Cluster) error {
+	out.TypeMeta = in.TypeMeta
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if err := Convert_v1beta1_ClusterSpec_To_v1alpha1_ClusterSpec(&in.Spec, &out.Spec); err != nil {
+		return err
+	}
+	return Convert_v1beta1_ClusterStatus_To_v1alpha1_ClusterStatus(&in.Status, &out.Status)
+}
+
+// Convert_v1alpha1_ClusterList_To_v1beta1_ClusterList converts a v1alpha1
+// ClusterList to a v1beta1 ClusterList.
+func Convert_v1alpha1_ClusterList_To_v1beta1_ClusterList(in *v1alpha1.ClusterList, out *ClusterList) error {
+	out.TypeMeta = in.TypeMeta
+	out.APIVersion = SchemeGroupVersion.String()
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = nil
+	if in.Items != nil {
+		out.Items = make([]Cluster, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1alpha1_Cluster_To_v1beta1_Cluster(&in.Items[i], &out.Items[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterList_To_v1alpha1_ClusterList converts a v1beta1
+// ClusterList to a v1alpha1 ClusterList.
+func Convert_v1beta1_ClusterList_To_v1alpha1_ClusterList(in *ClusterList, out *v1alpha1.ClusterList) error {
+	out.TypeMeta = in.TypeMeta
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	out.Items = nil
+	if in.Items != nil {
+		out.Items = make([]v1alpha1.Cluster, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1beta1_Cluster_To_v1alpha1_Cluster(&in.Items[i], &out.Items[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterSpec_To_v1beta1_ClusterSpec converts a v1alpha1
+// ClusterSpec to a v1beta1 ClusterSpec.
+func Convert_v1alpha1_ClusterSpec_To_v1beta1_ClusterSpec(in *v1alpha1.ClusterSpec, out *ClusterSpec) error {
+	endpoints := in.KubernetesAPIEndpoints.DeepCopy()
+	out.KubernetesAPIEndpoints.CABundle = endpoints.CABundle
+	out.KubernetesAPIEndpoints.ServerEndpoints = nil
+	if endpoints.ServerEndpoints != nil {
+		out.KubernetesAPIEndpoints.ServerEndpoints = make([]ServerAddressByClientCIDR, len(endpoints.ServerEndpoints))
+		for i, endpoint := range endpoints.ServerEndpoints {
+			out.KubernetesAPIEndpoints.ServerEndpoints[i] = ServerAddressByClientCIDR{
+				ClientCIDR:    endpoint.ClientCIDR,
+				ServerAddress: endpoint.ServerAddress,
+			}
+		}
+	}
+	out.AuthInfo = AuthInfo{
+		User:       convertObjectReferenceFromV1alpha1(in.AuthInfo.User),
+		Controller: convertObjectReferenceFromV1alpha1(in.AuthInfo.Controller),
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterSpec_To_v1alpha1_ClusterSpec converts a v1beta1
+// ClusterSpec to a v1alpha1 ClusterSpec.
+func Convert_v1beta1_ClusterSpec_To_v1alpha1_ClusterSpec(in *ClusterSpec, out *v1alpha1.ClusterSpec) error {
+	endpoints := in.KubernetesAPIEndpoints.DeepCopy()
+	out.KubernetesAPIEndpoints.CABundle = endpoints.CABundle
+	out.KubernetesAPIEndpoints.ServerEndpoints = nil
+	if endpoints.ServerEndpoints != nil {
+		out.KubernetesAPIEndpoints.ServerEndpoints = make([]v1alpha1.ServerAddressByClientCIDR, len(endpoints.ServerEndpoints))
+		for i, endpoint := range endpoints.ServerEndpoints {
+			out.KubernetesAPIEndpoints.ServerEndpoints[i] = v1alpha1.ServerAddressByClientCIDR{
+				ClientCIDR:    endpoint.ClientCIDR,
+				ServerAddress: endpoint.ServerAddress,
+			}
+		}
+	}
+	out.AuthInfo = v1alpha1.AuthInfo{
+		User:       convertObjectReferenceToV1alpha1(in.AuthInfo.User),
+		Controller: convertObjectReferenceToV1alpha1(in.AuthInfo.Controller),
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterStatus_To_v1beta1_ClusterStatus converts a v1alpha1
+// ClusterStatus to a v1beta1 ClusterStatus.
+func Convert_v1alpha1_ClusterStatus_To_v1beta1_ClusterStatus(in *v1alpha1.ClusterStatus, out *ClusterStatus) error {
+	in = in.DeepCopy()
+	out.Conditions = nil
+	if in.Conditions != nil {
+		out.Conditions = make([]ClusterCondition, len(in.Conditions))
+		for i, condition := range in.Conditions {
+			out.Conditions[i] = ClusterCondition{
+				Type:               ClusterConditionType(condition.Type),
+				Status:             condition.Status,
+				LastHeartbeatTime:  condition.LastHeartbeatTime,
+				LastTransitionTime: condition.LastTransitionTime,
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+			}
+		}
+	}
+	out.ServerVersion = in.ServerVersion
+	out.Platform = in.Platform
+	out.NodeCount = in.NodeCount
+	out.Capacity = in.Capacity
+	out.Allocatable = in.Allocatable
+	out.LastObservedTime = in.LastObservedTime
+	return nil
+}
+
+// Convert_v1beta1_ClusterStatus_To_v1alpha1_ClusterStatus converts a v1beta1
+// ClusterStatus to a v1alpha1 ClusterStatus.
+func Convert_v1beta1_ClusterStatus_To_v1alpha1_ClusterStatus(in *ClusterStatus, out *v1alpha1.ClusterStatus) error {
+	in = in.DeepCopy()
+	out.Conditions = nil
+	if in.Conditions != nil {
+		out.Conditions = make([]v1alpha1.ClusterCondition, len(in.Conditions))
+		for i, condition := range in.Conditions {
+			out.Conditions[i] = v1alpha1.ClusterCondition{
+				Type:               v1alpha1.ClusterConditionType(condition.Type),
+				Status:             condition.Status,
+				LastHeartbeatTime:  condition.LastHeartbeatTime,
+				LastTransitionTime: condition.LastTransitionTime,
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+			}
+		}
+	}
+	out.ServerVersion = in.ServerVersion
+	out.Platform = in.Platform
+	out.NodeCount = in.NodeCount
+	out.Capacity = in.Capacity
+	out.Allocatable = in.Allocatable
+	out.LastObservedTime = in.LastObservedTime
+	return nil
+}
+
+func convertObjectReferenceFromV1alpha1(in *v1alpha1.ObjectReference) *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	return &ObjectReference{Kind: in.Kind, Name: in.Name, Namespace: in.Namespace}
+}
+
+func convertObjectReferenceToV1alpha1(in *ObjectReference) *v1alpha1.ObjectReference {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha1.ObjectReference{Kind: in.Kind, Name: in.Name, Namespace: in.Namespace}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"math/rand"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+const fuzzIterations = 1000
+
+func newFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.New().RandSource(rand.NewSource(seed)).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(t *metav1.Time, c fuzz.Continue) {
+			// JSON serialization truncates times to seconds.
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+	)
+}
+
+func TestRoundTripFromV1alpha1(t *testing.T) {
+	f := newFuzzer(1)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1alpha1.Cluster{}
+		f.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{Kind: "Cluster", APIVersion: v1alpha1.SchemeGroupVersion.String()}
+		input := original.DeepCopy()
+
+		converted := &Cluster{}
+		if err := Convert_v1alpha1_Cluster_To_v1beta1_Cluster(input, converted); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if converted.APIVersion != SchemeGroupVersion.String() {
+			t.Errorf("Expected apiVersion %q, got %q", SchemeGroupVersion, converted.APIVersion)
+		}
+		roundTripped := &v1alpha1.Cluster{}
+		if err := Convert_v1beta1_Cluster_To_v1alpha1_Cluster(converted, roundTripped); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("Round trip through v1beta1 changed the object:\n%s", diff.ObjectReflectDiff(original, roundTripped))
+		}
+		if !equality.Semantic.DeepEqual(original, input) {
+			t.Fatalf("Conversion modified its input:\n%s", diff.ObjectReflectDiff(original, input))
+		}
+	}
+}
+
+func TestRoundTripFromV1beta1(t *testing.T) {
+	f := newFuzzer(2)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &Cluster{}
+		f.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{Kind: "Cluster", APIVersion: SchemeGroupVersion.String()}
+
+		converted := &v1alpha1.Cluster{}
+		if err := Convert_v1beta1_Cluster_To_v1alpha1_Cluster(original, converted); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if converted.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+			t.Errorf("Expected apiVersion %q, got %q", v1alpha1.SchemeGroupVersion, converted.APIVersion)
+		}
+		roundTripped := &Cluster{}
+		if err := Convert_v1alpha1_Cluster_To_v1beta1_Cluster(converted, roundTripped); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("Round trip through v1alpha1 changed the object:\n%s", diff.ObjectReflectDiff(original, roundTripped))
+		}
+	}
+}
+
+func TestRoundTripList(t *testing.T) {
+	f := newFuzzer(3)
+	for i := 0; i < fuzzIterations/10; i++ {
+		original := &v1alpha1.ClusterList{}
+		f.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{Kind: "ClusterList", APIVersion: v1alpha1.SchemeGroupVersion.String()}
+		for j := range original.Items {
+			original.Items[j].TypeMeta = metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String()}
+		}
+
+		converted := &ClusterList{}
+		if err := Convert_v1alpha1_ClusterList_To_v1beta1_ClusterList(original, converted); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		roundTripped := &v1alpha1.ClusterList{}
+		if err := Convert_v1beta1_ClusterList_To_v1alpha1_ClusterList(converted, roundTripped); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("Round trip through v1beta1 changed the list:\n%s", diff.ObjectReflectDiff(original, roundTripped))
+		}
+	}
+}
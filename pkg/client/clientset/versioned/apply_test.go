@@ -0,0 +1,109 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versioned
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+	applyv1beta1 "k8s.io/cluster-registry/pkg/client/applyconfiguration/clusterregistry/v1beta1"
+)
+
+// TestApply checks that Apply and ApplyStatus send server-side apply patches
+// with the given field manager, and that cancelling their context aborts the
+// request.
+func TestApply(t *testing.T) {
+	const fieldManager = "test-manager"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Expected a PATCH request, got %s", r.Method)
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != string(types.ApplyPatchType) {
+			t.Errorf("Expected a %s body, got %q", types.ApplyPatchType, contentType)
+		}
+		if got := r.URL.Query().Get("fieldManager"); got != fieldManager {
+			t.Errorf("Expected field manager %q, got %q", fieldManager, got)
+		}
+		if got := r.URL.Query().Get("force"); got != "true" {
+			t.Errorf("Expected force=true, got %q", got)
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		cluster := &v1beta1.Cluster{}
+		if err := json.Unmarshal(body, cluster); err != nil {
+			t.Errorf("Unexpected error decoding the request body: %v", err)
+		}
+		if cluster.APIVersion != v1beta1.SchemeGroupVersion.String() || cluster.Kind != "Cluster" {
+			t.Errorf("Expected a %s Cluster, got %s %s", v1beta1.SchemeGroupVersion, cluster.APIVersion, cluster.Kind)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cluster); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	clientset, err := NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	clusters := clientset.ClusterregistryV1beta1().Clusters("default")
+	options := metav1.ApplyOptions{FieldManager: fieldManager, Force: true}
+
+	cluster := applyv1beta1.Cluster("cluster", "default").
+		WithLabels(map[string]string{"env": "prod"}).
+		WithSpec(applyv1beta1.ClusterSpec().
+			WithKubernetesAPIEndpoints(applyv1beta1.KubernetesAPIEndpoints().
+				WithServerEndpoints(applyv1beta1.ServerAddressByClientCIDR().
+					WithClientCIDR("0.0.0.0/0").
+					WithServerAddress("cluster.example.com:443"))))
+	got, err := clusters.Apply(context.TODO(), cluster, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Labels["env"] != "prod" || len(got.Spec.KubernetesAPIEndpoints.ServerEndpoints) != 1 {
+		t.Errorf("Expected the applied cluster, got %v", got)
+	}
+
+	status := applyv1beta1.Cluster("cluster", "default").
+		WithStatus(applyv1beta1.ClusterStatus().WithServerVersion("v1.13.0"))
+	if got, err = clusters.ApplyStatus(context.TODO(), status, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Status.ServerVersion != "v1.13.0" {
+		t.Errorf("Expected server version v1.13.0, got %q", got.Status.ServerVersion)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := clusters.Apply(ctx, cluster, options); err == nil {
+		t.Errorf("Expected an error applying with a cancelled context")
+	}
+}
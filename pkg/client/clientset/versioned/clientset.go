@@ -19,24 +19,27 @@ limitations under the License.
 package versioned
 
 import (
+	"fmt"
+	"net/http"
+
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	clusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1alpha1"
+	clusterregistryv1beta1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1beta1"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	ClusterregistryV1alpha1() clusterregistryv1alpha1.ClusterregistryV1alpha1Interface
-	// Deprecated: please explicitly pick a version if possible.
-	Clusterregistry() clusterregistryv1alpha1.ClusterregistryV1alpha1Interface
+	ClusterregistryV1beta1() clusterregistryv1beta1.ClusterregistryV1beta1Interface
 }
 
-// Clientset contains the clients for groups. Each group has exactly one
-// version included in a Clientset.
+// Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	clusterregistryV1alpha1 *clusterregistryv1alpha1.ClusterregistryV1alpha1Client
+	clusterregistryV1beta1  *clusterregistryv1beta1.ClusterregistryV1beta1Client
 }
 
 // ClusterregistryV1alpha1 retrieves the ClusterregistryV1alpha1Client
@@ -44,10 +47,9 @@ func (c *Clientset) ClusterregistryV1alpha1() clusterregistryv1alpha1.Clusterreg
 	return c.clusterregistryV1alpha1
 }
 
-// Deprecated: Clusterregistry retrieves the default version of ClusterregistryClient.
-// Please explicitly pick a version.
-func (c *Clientset) Clusterregistry() clusterregistryv1alpha1.ClusterregistryV1alpha1Interface {
-	return c.clusterregistryV1alpha1
+// ClusterregistryV1beta1 retrieves the ClusterregistryV1beta1Client
+func (c *Clientset) ClusterregistryV1beta1() clusterregistryv1beta1.ClusterregistryV1beta1Interface {
+	return c.clusterregistryV1beta1
 }
 
 // Discovery retrieves the DiscoveryClient
@@ -59,19 +61,51 @@ func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 }
 
 // NewForConfig creates a new Clientset for the given config.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfig will generate a rate-limiter in configShallowCopy.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
 func NewForConfig(c *rest.Config) (*Clientset, error) {
 	configShallowCopy := *c
+
+	if configShallowCopy.UserAgent == "" {
+		configShallowCopy.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	// share the transport between all clients
+	httpClient, err := rest.HTTPClientFor(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewForConfigAndClient(&configShallowCopy, httpClient)
+}
+
+// NewForConfigAndClient creates a new Clientset for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+// If config's RateLimiter is not set and QPS and Burst are acceptable,
+// NewForConfigAndClient will generate a rate-limiter in configShallowCopy.
+func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset, error) {
+	configShallowCopy := *c
 	if configShallowCopy.RateLimiter == nil && configShallowCopy.QPS > 0 {
+		if configShallowCopy.Burst <= 0 {
+			return nil, fmt.Errorf("burst is required to be greater than 0 when RateLimiter is not set and QPS is set to greater than 0")
+		}
 		configShallowCopy.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(configShallowCopy.QPS, configShallowCopy.Burst)
 	}
+
 	var cs Clientset
 	var err error
-	cs.clusterregistryV1alpha1, err = clusterregistryv1alpha1.NewForConfig(&configShallowCopy)
+	cs.clusterregistryV1alpha1, err = clusterregistryv1alpha1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	cs.clusterregistryV1beta1, err = clusterregistryv1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
+	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -81,17 +115,18 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 // NewForConfigOrDie creates a new Clientset for the given config and
 // panics if there is an error in the config.
 func NewForConfigOrDie(c *rest.Config) *Clientset {
-	var cs Clientset
-	cs.clusterregistryV1alpha1 = clusterregistryv1alpha1.NewForConfigOrDie(c)
-
-	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
-	return &cs
+	cs, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return cs
 }
 
 // New creates a new Clientset for the given RESTClient.
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.clusterregistryV1alpha1 = clusterregistryv1alpha1.New(c)
+	cs.clusterregistryV1beta1 = clusterregistryv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -27,6 +27,8 @@ import (
 	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
 	clusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1alpha1"
 	fakeclusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1alpha1/fake"
+	clusterregistryv1beta1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1beta1"
+	fakeclusterregistryv1beta1 "k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1beta1/fake"
 )
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
@@ -41,7 +43,7 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 		}
 	}
 
-	cs := &Clientset{}
+	cs := &Clientset{tracker: o}
 	cs.discovery = &fakediscovery.FakeDiscovery{Fake: &cs.Fake}
 	cs.AddReactor("*", "*", testing.ObjectReaction(o))
 	cs.AddWatchReactor("*", func(action testing.Action) (handled bool, ret watch.Interface, err error) {
@@ -63,20 +65,28 @@ func NewSimpleClientset(objects ...runtime.Object) *Clientset {
 type Clientset struct {
 	testing.Fake
 	discovery *fakediscovery.FakeDiscovery
+	tracker   testing.ObjectTracker
 }
 
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	return c.discovery
 }
 
-var _ clientset.Interface = &Clientset{}
+func (c *Clientset) Tracker() testing.ObjectTracker {
+	return c.tracker
+}
+
+var (
+	_ clientset.Interface = &Clientset{}
+	_ testing.FakeClient  = &Clientset{}
+)
 
 // ClusterregistryV1alpha1 retrieves the ClusterregistryV1alpha1Client
 func (c *Clientset) ClusterregistryV1alpha1() clusterregistryv1alpha1.ClusterregistryV1alpha1Interface {
 	return &fakeclusterregistryv1alpha1.FakeClusterregistryV1alpha1{Fake: &c.Fake}
 }
 
-// Clusterregistry retrieves the ClusterregistryV1alpha1Client
-func (c *Clientset) Clusterregistry() clusterregistryv1alpha1.ClusterregistryV1alpha1Interface {
-	return &fakeclusterregistryv1alpha1.FakeClusterregistryV1alpha1{Fake: &c.Fake}
+// ClusterregistryV1beta1 retrieves the ClusterregistryV1beta1Client
+func (c *Clientset) ClusterregistryV1beta1() clusterregistryv1beta1.ClusterregistryV1beta1Interface {
+	return &fakeclusterregistryv1beta1.FakeClusterregistryV1beta1{Fake: &c.Fake}
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versioned
+
+import (
+	"context"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/scheme"
+)
+
+const protobufMediaType = "application/vnd.kubernetes.protobuf"
+
+// TestProtobufContentType checks that a clientset configured with the
+// protobuf content type sends and accepts protobuf-encoded Clusters.
+func TestProtobufContentType(t *testing.T) {
+	info, ok := runtime.SerializerInfoForMediaType(scheme.Codecs.SupportedMediaTypes(), protobufMediaType)
+	if !ok {
+		t.Fatalf("Expected the clientset codecs to support %s", protobufMediaType)
+	}
+	encoder := scheme.Codecs.EncoderForVersion(info.Serializer, v1beta1.SchemeGroupVersion)
+
+	cluster := &v1beta1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}}
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1beta1.ServerAddressByClientCIDR{
+		{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com:443"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The client may accept other media types after its preferred one.
+		preferred := strings.Split(r.Header.Get("Accept"), ",")[0]
+		if accept, _, _ := mime.ParseMediaType(preferred); accept != protobufMediaType {
+			t.Errorf("Expected to accept %s, got %q", protobufMediaType, r.Header.Get("Accept"))
+		}
+		if r.Method == http.MethodPost {
+			if contentType := r.Header.Get("Content-Type"); contentType != protobufMediaType {
+				t.Errorf("Expected a %s body, got %q", protobufMediaType, contentType)
+			}
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if _, _, err := info.Serializer.Decode(body, nil, &v1beta1.Cluster{}); err != nil {
+				t.Errorf("Unexpected error decoding the request body: %v", err)
+			}
+		}
+
+		w.Header().Set("Content-Type", protobufMediaType)
+		if err := encoder.Encode(cluster, w); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	config := &rest.Config{Host: server.URL}
+	config.ContentType = protobufMediaType
+	clientset, err := NewForConfig(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	clusters := clientset.ClusterregistryV1beta1().Clusters("default")
+
+	got, err := clusters.Get(context.TODO(), "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.Name != "cluster" || len(got.Spec.KubernetesAPIEndpoints.ServerEndpoints) != 1 {
+		t.Errorf("Expected %v, got %v", cluster, got)
+	}
+
+	if _, err := clusters.Create(context.TODO(), cluster, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
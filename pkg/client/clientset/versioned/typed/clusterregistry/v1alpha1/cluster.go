@@ -19,11 +19,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	rest "k8s.io/client-go/rest"
 	v1alpha1 "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	clusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/client/applyconfiguration/clusterregistry/v1alpha1"
 	scheme "k8s.io/cluster-registry/pkg/client/clientset/versioned/scheme"
 )
 
@@ -35,15 +41,17 @@ type ClustersGetter interface {
 
 // ClusterInterface has methods to work with Cluster resources.
 type ClusterInterface interface {
-	Create(*v1alpha1.Cluster) (*v1alpha1.Cluster, error)
-	Update(*v1alpha1.Cluster) (*v1alpha1.Cluster, error)
-	UpdateStatus(*v1alpha1.Cluster) (*v1alpha1.Cluster, error)
-	Delete(name string, options *v1.DeleteOptions) error
-	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
-	Get(name string, options v1.GetOptions) (*v1alpha1.Cluster, error)
-	List(opts v1.ListOptions) (*v1alpha1.ClusterList, error)
-	Watch(opts v1.ListOptions) (watch.Interface, error)
-	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Cluster, err error)
+	Create(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.CreateOptions) (*v1alpha1.Cluster, error)
+	Update(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (*v1alpha1.Cluster, error)
+	UpdateStatus(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (*v1alpha1.Cluster, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Cluster, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Cluster, err error)
+	Apply(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error)
+	ApplyStatus(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error)
 	ClusterExpansion
 }
 
@@ -62,113 +70,187 @@ func newClusters(c *ClusterregistryV1alpha1Client, namespace string) *clusters {
 }
 
 // Get takes name of the cluster, and returns the corresponding cluster object, and an error if there is any.
-func (c *clusters) Get(name string, options v1.GetOptions) (result *v1alpha1.Cluster, err error) {
+func (c *clusters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Cluster, err error) {
 	result = &v1alpha1.Cluster{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("clusters").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of Clusters that match those selectors.
-func (c *clusters) List(opts v1.ListOptions) (result *v1alpha1.ClusterList, err error) {
+func (c *clusters) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	result = &v1alpha1.ClusterList{}
 	err = c.client.Get().
 		Namespace(c.ns).
 		Resource("clusters").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Do().
+		Timeout(timeout).
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested clusters.
-func (c *clusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *clusters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
 	opts.Watch = true
 	return c.client.Get().
 		Namespace(c.ns).
 		Resource("clusters").
 		VersionedParams(&opts, scheme.ParameterCodec).
-		Watch()
+		Timeout(timeout).
+		Watch(ctx)
 }
 
 // Create takes the representation of a cluster and creates it.  Returns the server's representation of the cluster, and an error, if there is any.
-func (c *clusters) Create(cluster *v1alpha1.Cluster) (result *v1alpha1.Cluster, err error) {
+func (c *clusters) Create(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.CreateOptions) (result *v1alpha1.Cluster, err error) {
 	result = &v1alpha1.Cluster{}
 	err = c.client.Post().
 		Namespace(c.ns).
 		Resource("clusters").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(cluster).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a cluster and updates it. Returns the server's representation of the cluster, and an error, if there is any.
-func (c *clusters) Update(cluster *v1alpha1.Cluster) (result *v1alpha1.Cluster, err error) {
+func (c *clusters) Update(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (result *v1alpha1.Cluster, err error) {
 	result = &v1alpha1.Cluster{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("clusters").
 		Name(cluster.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(cluster).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-
-func (c *clusters) UpdateStatus(cluster *v1alpha1.Cluster) (result *v1alpha1.Cluster, err error) {
+func (c *clusters) UpdateStatus(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (result *v1alpha1.Cluster, err error) {
 	result = &v1alpha1.Cluster{}
 	err = c.client.Put().
 		Namespace(c.ns).
 		Resource("clusters").
 		Name(cluster.Name).
 		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
 		Body(cluster).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the cluster and deletes it. Returns an error if one occurs.
-func (c *clusters) Delete(name string, options *v1.DeleteOptions) error {
+func (c *clusters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("clusters").
 		Name(name).
-		Body(options).
-		Do().
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *clusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+func (c *clusters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
 	return c.client.Delete().
 		Namespace(c.ns).
 		Resource("clusters").
-		VersionedParams(&listOptions, scheme.ParameterCodec).
-		Body(options).
-		Do().
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched cluster.
-func (c *clusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Cluster, err error) {
+func (c *clusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Cluster, err error) {
 	result = &v1alpha1.Cluster{}
 	err = c.client.Patch(pt).
 		Namespace(c.ns).
 		Resource("clusters").
-		SubResource(subresources...).
 		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied cluster.
+func (c *clusters) Apply(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	name := cluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("cluster.Name must be provided to Apply")
+	}
+	result = &v1alpha1.Cluster{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("clusters").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *clusters) ApplyStatus(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	name := cluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("cluster.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.Cluster{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("clusters").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
 		Body(data).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
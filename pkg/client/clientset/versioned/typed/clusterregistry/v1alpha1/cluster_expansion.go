@@ -0,0 +1,64 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1alpha1/internal"
+)
+
+// The ClusterExpansion interface allows manually adding extra methods to the
+// ClusterInterface.
+type ClusterExpansion interface {
+	// MutateSpec gets the Cluster with the given name, calls mutate on it
+	// and updates the Cluster if mutate changed its metadata or spec. Updates
+	// that conflict with a concurrent write are retried with the latest
+	// version of the Cluster, so mutate may be called more than once and
+	// must only depend on the Cluster it is given. If mutate returns an
+	// error, MutateSpec returns it without updating the Cluster.
+	//
+	// MutateSpec returns the Cluster as persisted by the API server. Changes
+	// made by mutate to the status of the Cluster are ignored.
+	MutateSpec(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error)
+
+	// MutateStatus is like MutateSpec, but updates the status of the Cluster
+	// if mutate changed it. Changes made by mutate to the metadata or spec of
+	// the Cluster are ignored.
+	MutateStatus(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error)
+
+	// SetCondition adds condition to the status of the Cluster with the given
+	// name, or updates its existing condition of the same type, as
+	// helper.SetClusterCondition does, and returns the persisted Cluster.
+	// Every call records a new heartbeat; reporters that observe conditions
+	// frequently should use MutateStatus and helper.SetClusterCondition with
+	// a heartbeat granularity instead.
+	SetCondition(ctx context.Context, name string, condition v1alpha1.ClusterCondition) (*v1alpha1.Cluster, error)
+}
+
+func (c *clusters) MutateSpec(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return internal.MutateSpec(ctx, c, name, mutate)
+}
+
+func (c *clusters) MutateStatus(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return internal.MutateStatus(ctx, c, name, mutate)
+}
+
+func (c *clusters) SetCondition(ctx context.Context, name string, condition v1alpha1.ClusterCondition) (*v1alpha1.Cluster, error) {
+	return internal.SetCondition(ctx, c, name, condition)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+)
+
+func newClientset() *fake.Clientset {
+	return fake.NewSimpleClientset(&v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+	})
+}
+
+// updates returns the update actions recorded by client, and the subresource
+// each of them targeted.
+func updates(client *fake.Clientset) []string {
+	var subresources []string
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" {
+			subresources = append(subresources, action.GetSubresource())
+		}
+	}
+	return subresources
+}
+
+func TestMutateSpec(t *testing.T) {
+	client := newClientset()
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	cluster, err := clusters.MutateSpec(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		cluster.Labels = map[string]string{"env": "prod"}
+		cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1alpha1.ServerAddressByClientCIDR{
+			{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com:443"},
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cluster.Labels["env"] != "prod" || len(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints) != 1 {
+		t.Errorf("Expected the mutated cluster, got %v", cluster)
+	}
+	if subresources := updates(client); len(subresources) != 1 || subresources[0] != "" {
+		t.Errorf("Expected one update of the cluster, got updates of %q", subresources)
+	}
+
+	persisted, err := clusters.Get(context.TODO(), "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if persisted.Labels["env"] != "prod" {
+		t.Errorf("Expected the mutation to be persisted, got %v", persisted)
+	}
+}
+
+func TestMutateSpecUnchanged(t *testing.T) {
+	client := newClientset()
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	cluster, err := clusters.MutateSpec(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		// Status changes are not written by MutateSpec.
+		cluster.Status.ServerVersion = "v1.13.0"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cluster.Name != "cluster" || cluster.Status.ServerVersion != "" {
+		t.Errorf("Expected the unchanged cluster, got %v", cluster)
+	}
+	if subresources := updates(client); len(subresources) != 0 {
+		t.Errorf("Expected no updates, got updates of %q", subresources)
+	}
+}
+
+func TestMutateSpecConflict(t *testing.T) {
+	client := newClientset()
+	conflicts := 2
+	client.PrependReactor("update", "clusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if conflicts == 0 {
+			return false, nil, nil
+		}
+		conflicts--
+		return true, nil, apierrors.NewConflict(v1alpha1.Resource("clusters"), "cluster", errors.New("the object has been modified"))
+	})
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	calls := 0
+	cluster, err := clusters.MutateSpec(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		calls++
+		cluster.Labels = map[string]string{"env": "prod"}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected mutate to be called 3 times, got %d", calls)
+	}
+	if cluster.Labels["env"] != "prod" {
+		t.Errorf("Expected the mutated cluster, got %v", cluster)
+	}
+}
+
+func TestMutateSpecErrors(t *testing.T) {
+	client := newClientset()
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	mutateErr := errors.New("mutate failed")
+	if _, err := clusters.MutateSpec(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		cluster.Labels = map[string]string{"env": "prod"}
+		return mutateErr
+	}); err != mutateErr {
+		t.Errorf("Expected %v, got %v", mutateErr, err)
+	}
+	if subresources := updates(client); len(subresources) != 0 {
+		t.Errorf("Expected no updates, got updates of %q", subresources)
+	}
+
+	if _, err := clusters.MutateSpec(context.TODO(), "missing", func(*v1alpha1.Cluster) error {
+		t.Errorf("Expected mutate not to be called for a missing cluster")
+		return nil
+	}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected a NotFound error, got %v", err)
+	}
+}
+
+func TestMutateStatus(t *testing.T) {
+	client := newClientset()
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	cluster, err := clusters.MutateStatus(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		cluster.Status.ServerVersion = "v1.13.0"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cluster.Status.ServerVersion != "v1.13.0" {
+		t.Errorf("Expected server version v1.13.0, got %q", cluster.Status.ServerVersion)
+	}
+	if subresources := updates(client); len(subresources) != 1 || subresources[0] != "status" {
+		t.Errorf("Expected one update of the status, got updates of %q", subresources)
+	}
+
+	client.ClearActions()
+	if _, err := clusters.MutateStatus(context.TODO(), "cluster", func(cluster *v1alpha1.Cluster) error {
+		cluster.Labels = map[string]string{"env": "prod"}
+		return nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if subresources := updates(client); len(subresources) != 0 {
+		t.Errorf("Expected no updates, got updates of %q", subresources)
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	client := newClientset()
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+
+	ready := v1alpha1.ClusterCondition{Type: v1alpha1.ClusterReady, Status: corev1.ConditionTrue, Reason: "Ready"}
+	cluster, err := clusters.SetCondition(context.TODO(), "cluster", ready)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	condition := helper.GetClusterCondition(&cluster.Status, v1alpha1.ClusterReady)
+	if condition == nil || condition.Status != corev1.ConditionTrue || condition.Reason != "Ready" {
+		t.Fatalf("Expected a True Ready condition, got %v", cluster.Status.Conditions)
+	}
+	if condition.LastTransitionTime.IsZero() || condition.LastHeartbeatTime.IsZero() {
+		t.Errorf("Expected the condition times to be set, got %v", condition)
+	}
+
+	notReady := v1alpha1.ClusterCondition{Type: v1alpha1.ClusterReady, Status: corev1.ConditionFalse, Reason: "NotReady"}
+	if cluster, err = clusters.SetCondition(context.TODO(), "cluster", notReady); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cluster.Status.Conditions) != 1 {
+		t.Fatalf("Expected the condition to be replaced, got %v", cluster.Status.Conditions)
+	}
+	if condition := cluster.Status.Conditions[0]; condition.Status != corev1.ConditionFalse || condition.Reason != "NotReady" {
+		t.Errorf("Expected a False Ready condition, got %v", condition)
+	}
+}
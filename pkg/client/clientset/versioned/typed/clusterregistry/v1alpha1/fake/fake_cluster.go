@@ -19,6 +19,10 @@ limitations under the License.
 package fake
 
 import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -26,6 +30,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
 	v1alpha1 "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	clusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/client/applyconfiguration/clusterregistry/v1alpha1"
 )
 
 // FakeClusters implements ClusterInterface
@@ -39,7 +44,7 @@ var clustersResource = schema.GroupVersionResource{Group: "clusterregistry.k8s.i
 var clustersKind = schema.GroupVersionKind{Group: "clusterregistry.k8s.io", Version: "v1alpha1", Kind: "Cluster"}
 
 // Get takes name of the cluster, and returns the corresponding cluster object, and an error if there is any.
-func (c *FakeClusters) Get(name string, options v1.GetOptions) (result *v1alpha1.Cluster, err error) {
+func (c *FakeClusters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Cluster, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(clustersResource, c.ns, name), &v1alpha1.Cluster{})
 
@@ -50,7 +55,7 @@ func (c *FakeClusters) Get(name string, options v1.GetOptions) (result *v1alpha1
 }
 
 // List takes label and field selectors, and returns the list of Clusters that match those selectors.
-func (c *FakeClusters) List(opts v1.ListOptions) (result *v1alpha1.ClusterList, err error) {
+func (c *FakeClusters) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterList, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(clustersResource, clustersKind, c.ns, opts), &v1alpha1.ClusterList{})
 
@@ -72,14 +77,14 @@ func (c *FakeClusters) List(opts v1.ListOptions) (result *v1alpha1.ClusterList,
 }
 
 // Watch returns a watch.Interface that watches the requested clusters.
-func (c *FakeClusters) Watch(opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeClusters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(clustersResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a cluster and creates it.  Returns the server's representation of the cluster, and an error, if there is any.
-func (c *FakeClusters) Create(cluster *v1alpha1.Cluster) (result *v1alpha1.Cluster, err error) {
+func (c *FakeClusters) Create(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.CreateOptions) (result *v1alpha1.Cluster, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(clustersResource, c.ns, cluster), &v1alpha1.Cluster{})
 
@@ -90,7 +95,7 @@ func (c *FakeClusters) Create(cluster *v1alpha1.Cluster) (result *v1alpha1.Clust
 }
 
 // Update takes the representation of a cluster and updates it. Returns the server's representation of the cluster, and an error, if there is any.
-func (c *FakeClusters) Update(cluster *v1alpha1.Cluster) (result *v1alpha1.Cluster, err error) {
+func (c *FakeClusters) Update(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (result *v1alpha1.Cluster, err error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(clustersResource, c.ns, cluster), &v1alpha1.Cluster{})
 
@@ -102,7 +107,7 @@ func (c *FakeClusters) Update(cluster *v1alpha1.Cluster) (result *v1alpha1.Clust
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeClusters) UpdateStatus(cluster *v1alpha1.Cluster) (*v1alpha1.Cluster, error) {
+func (c *FakeClusters) UpdateStatus(ctx context.Context, cluster *v1alpha1.Cluster, opts v1.UpdateOptions) (*v1alpha1.Cluster, error) {
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateSubresourceAction(clustersResource, "status", c.ns, cluster), &v1alpha1.Cluster{})
 
@@ -113,25 +118,70 @@ func (c *FakeClusters) UpdateStatus(cluster *v1alpha1.Cluster) (*v1alpha1.Cluste
 }
 
 // Delete takes name of the cluster and deletes it. Returns an error if one occurs.
-func (c *FakeClusters) Delete(name string, options *v1.DeleteOptions) error {
+func (c *FakeClusters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
-		Invokes(testing.NewDeleteAction(clustersResource, c.ns, name), &v1alpha1.Cluster{})
+		Invokes(testing.NewDeleteActionWithOptions(clustersResource, c.ns, name, opts), &v1alpha1.Cluster{})
 
 	return err
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeClusters) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
-	action := testing.NewDeleteCollectionAction(clustersResource, c.ns, listOptions)
+func (c *FakeClusters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(clustersResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched cluster.
-func (c *FakeClusters) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Cluster, err error) {
+func (c *FakeClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Cluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(clustersResource, c.ns, name, pt, data, subresources...), &v1alpha1.Cluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Cluster), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied cluster.
+func (c *FakeClusters) Apply(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	name := cluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("cluster.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(clustersResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.Cluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Cluster), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeClusters) ApplyStatus(ctx context.Context, cluster *clusterregistryv1alpha1.ClusterApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Cluster, err error) {
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	name := cluster.Name
+	if name == nil {
+		return nil, fmt.Errorf("cluster.Name must be provided to Apply")
+	}
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(clustersResource, c.ns, name, data, subresources...), &v1alpha1.Cluster{})
+		Invokes(testing.NewPatchSubresourceAction(clustersResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.Cluster{})
 
 	if obj == nil {
 		return nil, err
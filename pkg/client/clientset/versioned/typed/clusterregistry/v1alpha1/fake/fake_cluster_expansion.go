@@ -0,0 +1,36 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/typed/clusterregistry/v1alpha1/internal"
+)
+
+func (c *FakeClusters) MutateSpec(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return internal.MutateSpec(ctx, c, name, mutate)
+}
+
+func (c *FakeClusters) MutateStatus(ctx context.Context, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return internal.MutateStatus(ctx, c, name, mutate)
+}
+
+func (c *FakeClusters) SetCondition(ctx context.Context, name string, condition v1alpha1.ClusterCondition) (*v1alpha1.Cluster, error) {
+	return internal.SetCondition(ctx, c, name, condition)
+}
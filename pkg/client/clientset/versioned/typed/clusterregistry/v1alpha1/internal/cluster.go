@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package internal implements the ClusterExpansion methods shared by the
+// typed and the fake Cluster clients.
+package internal
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+)
+
+// Clusters is the part of a Cluster client used to mutate Clusters.
+type Clusters interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Cluster, error)
+	Update(ctx context.Context, cluster *v1alpha1.Cluster, opts metav1.UpdateOptions) (*v1alpha1.Cluster, error)
+	UpdateStatus(ctx context.Context, cluster *v1alpha1.Cluster, opts metav1.UpdateOptions) (*v1alpha1.Cluster, error)
+}
+
+// MutateSpec implements ClusterExpansion.MutateSpec.
+func MutateSpec(ctx context.Context, clusters Clusters, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return mutateCluster(ctx, clusters.Get, clusters.Update, name, mutate, func(a, b *v1alpha1.Cluster) bool {
+		return apiequality.Semantic.DeepEqual(a.ObjectMeta, b.ObjectMeta) && apiequality.Semantic.DeepEqual(a.Spec, b.Spec)
+	})
+}
+
+// MutateStatus implements ClusterExpansion.MutateStatus.
+func MutateStatus(ctx context.Context, clusters Clusters, name string, mutate func(*v1alpha1.Cluster) error) (*v1alpha1.Cluster, error) {
+	return mutateCluster(ctx, clusters.Get, clusters.UpdateStatus, name, mutate, func(a, b *v1alpha1.Cluster) bool {
+		return apiequality.Semantic.DeepEqual(a.Status, b.Status)
+	})
+}
+
+// SetCondition implements ClusterExpansion.SetCondition.
+func SetCondition(ctx context.Context, clusters Clusters, name string, condition v1alpha1.ClusterCondition) (*v1alpha1.Cluster, error) {
+	return MutateStatus(ctx, clusters, name, func(cluster *v1alpha1.Cluster) error {
+		helper.SetClusterCondition(&cluster.Status, condition, 0)
+		return nil
+	})
+}
+
+type getFunc func(ctx context.Context, name string, opts metav1.GetOptions) (*v1alpha1.Cluster, error)
+
+type updateFunc func(ctx context.Context, cluster *v1alpha1.Cluster, opts metav1.UpdateOptions) (*v1alpha1.Cluster, error)
+
+// mutateCluster gets the Cluster with the given name, calls mutate on a copy
+// of it and writes the copy back with update unless equal reports that mutate
+// left it unchanged. Conflicting writes are retried with a fresh copy of the
+// Cluster.
+func mutateCluster(ctx context.Context, get getFunc, update updateFunc, name string, mutate func(*v1alpha1.Cluster) error, equal func(a, b *v1alpha1.Cluster) bool) (*v1alpha1.Cluster, error) {
+	var result *v1alpha1.Cluster
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		mutated := cluster.DeepCopy()
+		if err := mutate(mutated); err != nil {
+			return err
+		}
+		if equal(cluster, mutated) {
+			result = cluster
+			return nil
+		}
+		result, err = update(ctx, mutated, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
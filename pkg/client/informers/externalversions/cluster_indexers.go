@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/tools/cache"
+
+	versioned "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	v1alpha1 "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1alpha1"
+	internalinterfaces "k8s.io/cluster-registry/pkg/client/informers/externalversions/internalinterfaces"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+)
+
+// ClusterIndexers returns the indexes of an indexed v1alpha1 Cluster
+// informer: the namespace index that every generated informer has, and the
+// listers.ClusterIndexers used by the Cluster lister queries.
+func ClusterIndexers() cache.Indexers {
+	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	for name, indexFunc := range listers.ClusterIndexers {
+		indexers[name] = indexFunc
+	}
+	return indexers
+}
+
+// NewIndexedClusterInformer constructs a new informer for v1alpha1 Clusters
+// with the ClusterIndexers.
+// Always prefer using IndexedClusterInformer to get a shared informer instead
+// of getting an independent one. This reduces memory footprint and number of
+// connections to the server.
+func NewIndexedClusterInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return v1alpha1.NewFilteredClusterInformer(client, namespace, resyncPeriod, ClusterIndexers(), tweakListOptions)
+}
+
+// IndexedClusterInformer returns the shared v1alpha1 Cluster informer of
+// factory after adding the ClusterIndexers it does not have yet. Prefer
+// calling it before factory is started, so that the indexes are built as the
+// cache is filled rather than over an already filled cache.
+func IndexedClusterInformer(factory SharedInformerFactory) (v1alpha1.ClusterInformer, error) {
+	informer := factory.Clusterregistry().V1alpha1().Clusters()
+	existing := informer.Informer().GetIndexer().GetIndexers()
+	missing := cache.Indexers{}
+	for name, indexFunc := range ClusterIndexers() {
+		if _, ok := existing[name]; !ok {
+			missing[name] = indexFunc
+		}
+	}
+	if len(missing) > 0 {
+		if err := informer.Informer().AddIndexers(missing); err != nil {
+			return nil, errors.Wrap(err, "failed to add the cluster indexers")
+		}
+	}
+	return informer, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+)
+
+func newCluster(namespace, name, address string) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1alpha1.ServerAddressByClientCIDR{
+		{ClientCIDR: "0.0.0.0/0", ServerAddress: address},
+	}
+	return cluster
+}
+
+func expectIndexers(t *testing.T, informer cache.SharedIndexInformer) {
+	indexers := informer.GetIndexer().GetIndexers()
+	for name := range ClusterIndexers() {
+		if _, ok := indexers[name]; !ok {
+			t.Errorf("Expected the %q index, got %v", name, indexers)
+		}
+	}
+}
+
+func TestIndexedClusterInformer(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newCluster("a", "one", "one.example.com"),
+		newCluster("b", "two", "https://two.example.com:6443"),
+	)
+
+	tests := []struct {
+		name  string
+		setup func(SharedInformerFactory)
+	}{
+		{"new informer", func(SharedInformerFactory) {}},
+		{"existing informer", func(factory SharedInformerFactory) {
+			factory.Clusterregistry().V1alpha1().Clusters().Informer()
+		}},
+		{"indexed informer", func(factory SharedInformerFactory) {
+			if _, err := IndexedClusterInformer(factory); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			factory := NewSharedInformerFactory(client, 0)
+			tc.setup(factory)
+			informer, err := IndexedClusterInformer(factory)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if informer.Informer() != factory.Clusterregistry().V1alpha1().Clusters().Informer() {
+				t.Errorf("Expected the shared informer of the factory")
+			}
+			expectIndexers(t, informer.Informer())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			factory.Start(stopCh)
+			factory.WaitForCacheSync(stopCh)
+
+			keys, err := informer.Informer().GetIndexer().IndexKeys(listers.ServerHostIndex, "two.example.com")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(keys) != 1 || keys[0] != "b/two" {
+				t.Errorf("Expected [b/two], got %v", keys)
+			}
+			cluster, err := informer.Lister().GetByServerAddress("one.example.com")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cluster.Name != "one" {
+				t.Errorf("Expected cluster one, got %s", cluster.Name)
+			}
+		})
+	}
+}
+
+func TestNewIndexedClusterInformer(t *testing.T) {
+	client := fake.NewSimpleClientset(newCluster("a", "one", "one.example.com"))
+	informer := NewIndexedClusterInformer(client, "a", 0, nil)
+	expectIndexers(t, informer)
+}
@@ -20,6 +20,7 @@ package clusterregistry
 
 import (
 	v1alpha1 "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1alpha1"
+	v1beta1 "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1beta1"
 	internalinterfaces "k8s.io/cluster-registry/pkg/client/informers/externalversions/internalinterfaces"
 )
 
@@ -27,6 +28,8 @@ import (
 type Interface interface {
 	// V1alpha1 provides access to shared informers for resources in V1alpha1.
 	V1alpha1() v1alpha1.Interface
+	// V1beta1 provides access to shared informers for resources in V1beta1.
+	V1beta1() v1beta1.Interface
 }
 
 type group struct {
@@ -44,3 +47,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (g *group) V1alpha1() v1alpha1.Interface {
 	return v1alpha1.New(g.factory, g.namespace, g.tweakListOptions)
 }
+
+// V1beta1 returns a new v1beta1.Interface.
+func (g *group) V1beta1() v1beta1.Interface {
+	return v1beta1.New(g.factory, g.namespace, g.tweakListOptions)
+}
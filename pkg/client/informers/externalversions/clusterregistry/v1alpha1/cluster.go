@@ -19,13 +19,14 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	time "time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	watch "k8s.io/apimachinery/pkg/watch"
 	cache "k8s.io/client-go/tools/cache"
-	clusterregistry_v1alpha1 "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	clusterregistryv1alpha1 "k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
 	versioned "k8s.io/cluster-registry/pkg/client/clientset/versioned"
 	internalinterfaces "k8s.io/cluster-registry/pkg/client/informers/externalversions/internalinterfaces"
 	v1alpha1 "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
@@ -61,16 +62,16 @@ func NewFilteredClusterInformer(client versioned.Interface, namespace string, re
 				if tweakListOptions != nil {
 					tweakListOptions(&options)
 				}
-				return client.ClusterregistryV1alpha1().Clusters(namespace).List(options)
+				return client.ClusterregistryV1alpha1().Clusters(namespace).List(context.TODO(), options)
 			},
 			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
 				if tweakListOptions != nil {
 					tweakListOptions(&options)
 				}
-				return client.ClusterregistryV1alpha1().Clusters(namespace).Watch(options)
+				return client.ClusterregistryV1alpha1().Clusters(namespace).Watch(context.TODO(), options)
 			},
 		},
-		&clusterregistry_v1alpha1.Cluster{},
+		&clusterregistryv1alpha1.Cluster{},
 		resyncPeriod,
 		indexers,
 	)
@@ -81,7 +82,7 @@ func (f *clusterInformer) defaultInformer(client versioned.Interface, resyncPeri
 }
 
 func (f *clusterInformer) Informer() cache.SharedIndexInformer {
-	return f.factory.InformerFor(&clusterregistry_v1alpha1.Cluster{}, f.defaultInformer)
+	return f.factory.InformerFor(&clusterregistryv1alpha1.Cluster{}, f.defaultInformer)
 }
 
 func (f *clusterInformer) Lister() v1alpha1.ClusterLister {
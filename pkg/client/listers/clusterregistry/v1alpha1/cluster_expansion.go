@@ -0,0 +1,223 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// hasCondition returns true if cluster has a condition of type conditionType
+// with the given status.
+func hasCondition(cluster *v1alpha1.Cluster, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) bool {
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// byIndex returns the Clusters in namespace, or in all namespaces if it is
+// empty, whose indexName index contains value. The Clusters are looked up in
+// the index if indexer has it, and found by scanning every Cluster with
+// match otherwise.
+func byIndex(indexer cache.Indexer, namespace, indexName, value string, match func(*v1alpha1.Cluster) bool) ([]*v1alpha1.Cluster, error) {
+	var objs []interface{}
+	if _, ok := indexer.GetIndexers()[indexName]; ok {
+		var err error
+		if objs, err = indexer.ByIndex(indexName, value); err != nil {
+			return nil, err
+		}
+	} else {
+		objs = indexer.List()
+	}
+
+	var ret []*v1alpha1.Cluster
+	for _, obj := range objs {
+		cluster := obj.(*v1alpha1.Cluster)
+		if (namespace == metav1.NamespaceAll || cluster.Namespace == namespace) && match(cluster) {
+			ret = append(ret, cluster)
+		}
+	}
+	return ret, nil
+}
+
+func listByCondition(indexer cache.Indexer, namespace string, selector labels.Selector, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error) {
+	return byIndex(indexer, namespace, ConditionIndex, conditionValue(conditionType, status), func(cluster *v1alpha1.Cluster) bool {
+		return selector.Matches(labels.Set(cluster.Labels)) && hasCondition(cluster, conditionType, status)
+	})
+}
+
+func getByServerAddress(indexer cache.Indexer, namespace, address string) (*v1alpha1.Cluster, error) {
+	address = v1alpha1.NormalizeServerAddress(address)
+	clusters, err := byIndex(indexer, namespace, ServerAddressIndex, address, func(cluster *v1alpha1.Cluster) bool {
+		return contains(serverAddresses(cluster), address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(clusters) {
+	case 0:
+		return nil, apierrors.NewNotFound(v1alpha1.Resource("cluster"), address)
+	case 1:
+		return clusters[0], nil
+	}
+	return nil, errors.Errorf("%d clusters have the server address %s", len(clusters), address)
+}
+
+func listReferencingSecret(indexer cache.Indexer, namespace, secretNamespace, secretName string) ([]*v1alpha1.Cluster, error) {
+	key := secretNamespace + "/" + secretName
+	return byIndex(indexer, namespace, SecretReferenceIndex, key, func(cluster *v1alpha1.Cluster) bool {
+		return contains(secretReferences(cluster), key)
+	})
+}
+
+func listByServerHost(indexer cache.Indexer, namespace, host string) ([]*v1alpha1.Cluster, error) {
+	host = strings.ToLower(host)
+	return byIndex(indexer, namespace, ServerHostIndex, host, func(cluster *v1alpha1.Cluster) bool {
+		return contains(serverHosts(cluster), host)
+	})
+}
+
+func listByCABundleFingerprint(indexer cache.Indexer, namespace, fingerprint string) ([]*v1alpha1.Cluster, error) {
+	fingerprint = strings.ToLower(fingerprint)
+	return byIndex(indexer, namespace, CABundleIndex, fingerprint, func(cluster *v1alpha1.Cluster) bool {
+		return contains(CABundleFingerprints(cluster.Spec.KubernetesAPIEndpoints.CABundle), fingerprint)
+	})
+}
+
+// ClusterListerExpansion allows custom methods to be added to
+// ClusterLister.
+type ClusterListerExpansion interface {
+	// ListReady lists the Clusters whose ClusterReady condition is True. The
+	// cluster status controller in
+	// k8s.io/cluster-registry/pkg/controller/clusterstatus maintains that
+	// condition; without it, or another reporter of ClusterReady, ListReady
+	// returns no Clusters. Use ListByCondition with ClusterOK to filter on the
+	// legacy condition instead.
+	ListReady() ([]*v1alpha1.Cluster, error)
+	// ListByCondition lists the Clusters that have a condition of type
+	// conditionType with the given status.
+	ListByCondition(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error)
+	// ListBySelectorAndCondition lists the Clusters that match selector and
+	// have a condition of type conditionType with the given status.
+	ListBySelectorAndCondition(selector labels.Selector, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error)
+	// GetByServerAddress retrieves the Cluster that has an endpoint with the
+	// given server address. Addresses are compared after normalization by
+	// v1alpha1.NormalizeServerAddress, so cluster.example.com matches
+	// https://cluster.example.com:443. It returns a NotFound error if no
+	// Cluster has the address, and an error if more than one has it.
+	GetByServerAddress(address string) (*v1alpha1.Cluster, error)
+	// ListReferencingSecret lists the Clusters whose AuthInfo references the
+	// Secret with the given namespace and name.
+	ListReferencingSecret(secretNamespace, secretName string) ([]*v1alpha1.Cluster, error)
+	// ListByServerHost lists the Clusters that have an endpoint on host,
+	// on any port.
+	ListByServerHost(host string) ([]*v1alpha1.Cluster, error)
+	// ListByCABundleFingerprint lists the Clusters whose CA bundle contains
+	// the certificate with the given SHA-256 fingerprint, as returned by
+	// CABundleFingerprints.
+	ListByCABundleFingerprint(fingerprint string) ([]*v1alpha1.Cluster, error)
+}
+
+func (s *clusterLister) ListReady() ([]*v1alpha1.Cluster, error) {
+	return s.ListByCondition(v1alpha1.ClusterReady, corev1.ConditionTrue)
+}
+
+func (s *clusterLister) ListByCondition(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error) {
+	return listByCondition(s.indexer, metav1.NamespaceAll, labels.Everything(), conditionType, status)
+}
+
+func (s *clusterLister) ListBySelectorAndCondition(selector labels.Selector, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error) {
+	return listByCondition(s.indexer, metav1.NamespaceAll, selector, conditionType, status)
+}
+
+func (s *clusterLister) GetByServerAddress(address string) (*v1alpha1.Cluster, error) {
+	return getByServerAddress(s.indexer, metav1.NamespaceAll, address)
+}
+
+func (s *clusterLister) ListReferencingSecret(secretNamespace, secretName string) ([]*v1alpha1.Cluster, error) {
+	return listReferencingSecret(s.indexer, metav1.NamespaceAll, secretNamespace, secretName)
+}
+
+func (s *clusterLister) ListByServerHost(host string) ([]*v1alpha1.Cluster, error) {
+	return listByServerHost(s.indexer, metav1.NamespaceAll, host)
+}
+
+func (s *clusterLister) ListByCABundleFingerprint(fingerprint string) ([]*v1alpha1.Cluster, error) {
+	return listByCABundleFingerprint(s.indexer, metav1.NamespaceAll, fingerprint)
+}
+
+// ClusterNamespaceListerExpansion allows custom methods to be added to
+// ClusterNamespaceLister. Its methods are those of ClusterListerExpansion,
+// restricted to the Clusters of a single namespace.
+type ClusterNamespaceListerExpansion interface {
+	ListReady() ([]*v1alpha1.Cluster, error)
+	ListByCondition(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error)
+	ListBySelectorAndCondition(selector labels.Selector, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error)
+	GetByServerAddress(address string) (*v1alpha1.Cluster, error)
+	ListReferencingSecret(secretNamespace, secretName string) ([]*v1alpha1.Cluster, error)
+	ListByServerHost(host string) ([]*v1alpha1.Cluster, error)
+	ListByCABundleFingerprint(fingerprint string) ([]*v1alpha1.Cluster, error)
+}
+
+func (s clusterNamespaceLister) ListReady() ([]*v1alpha1.Cluster, error) {
+	return s.ListByCondition(v1alpha1.ClusterReady, corev1.ConditionTrue)
+}
+
+func (s clusterNamespaceLister) ListByCondition(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error) {
+	return listByCondition(s.indexer, s.namespace, labels.Everything(), conditionType, status)
+}
+
+func (s clusterNamespaceLister) ListBySelectorAndCondition(selector labels.Selector, conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) ([]*v1alpha1.Cluster, error) {
+	return listByCondition(s.indexer, s.namespace, selector, conditionType, status)
+}
+
+func (s clusterNamespaceLister) GetByServerAddress(address string) (*v1alpha1.Cluster, error) {
+	return getByServerAddress(s.indexer, s.namespace, address)
+}
+
+func (s clusterNamespaceLister) ListReferencingSecret(secretNamespace, secretName string) ([]*v1alpha1.Cluster, error) {
+	return listReferencingSecret(s.indexer, s.namespace, secretNamespace, secretName)
+}
+
+func (s clusterNamespaceLister) ListByServerHost(host string) ([]*v1alpha1.Cluster, error) {
+	return listByServerHost(s.indexer, s.namespace, host)
+}
+
+func (s clusterNamespaceLister) ListByCABundleFingerprint(fingerprint string) ([]*v1alpha1.Cluster, error) {
+	return listByCABundleFingerprint(s.indexer, s.namespace, fingerprint)
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+type testCluster struct {
+	namespace, name string
+	labels          map[string]string
+	addresses       []string
+	caBundle        []byte
+	user, ctrl      *v1alpha1.ObjectReference
+	conditions      map[v1alpha1.ClusterConditionType]corev1.ConditionStatus
+}
+
+func (tc testCluster) cluster() *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Name: tc.name, Labels: tc.labels},
+	}
+	for _, address := range tc.addresses {
+		cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = append(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints,
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: address})
+	}
+	cluster.Spec.KubernetesAPIEndpoints.CABundle = tc.caBundle
+	cluster.Spec.AuthInfo.User = tc.user
+	cluster.Spec.AuthInfo.Controller = tc.ctrl
+	for conditionType, status := range tc.conditions {
+		cluster.Status.Conditions = append(cluster.Status.Conditions, v1alpha1.ClusterCondition{Type: conditionType, Status: status})
+	}
+	return cluster
+}
+
+// testCertificates are stand-ins for the DER-encoded certificates of two
+// CAs; CABundleFingerprints does not parse them.
+var testCertificates = [][]byte{[]byte("certificate A"), []byte("certificate B")}
+
+func caBundle(certificates ...[]byte) []byte {
+	var bundle []byte
+	for _, certificate := range certificates {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})...)
+	}
+	return bundle
+}
+
+func fingerprint(certificate []byte) string {
+	sum := sha256.Sum256(certificate)
+	return hex.EncodeToString(sum[:])
+}
+
+var testClusters = []testCluster{
+	{
+		namespace: "a", name: "ready",
+		labels:     map[string]string{"env": "prod"},
+		addresses:  []string{"https://ready.example.com", "10.0.0.1:6443"},
+		caBundle:   caBundle(testCertificates[0]),
+		user:       &v1alpha1.ObjectReference{Name: "creds"},
+		conditions: map[v1alpha1.ClusterConditionType]corev1.ConditionStatus{v1alpha1.ClusterReady: corev1.ConditionTrue, v1alpha1.ClusterOK: corev1.ConditionTrue},
+	},
+	{
+		namespace: "a", name: "not-ready",
+		labels:     map[string]string{"env": "dev"},
+		addresses:  []string{"not-ready.example.com:8443"},
+		ctrl:       &v1alpha1.ObjectReference{Kind: "Secret", Namespace: "secrets", Name: "creds"},
+		conditions: map[v1alpha1.ClusterConditionType]corev1.ConditionStatus{v1alpha1.ClusterReady: corev1.ConditionFalse},
+	},
+	{
+		// ok is only reported healthy in the legacy ClusterOK condition, so
+		// ListReady does not return it.
+		namespace: "a", name: "ok",
+		conditions: map[v1alpha1.ClusterConditionType]corev1.ConditionStatus{v1alpha1.ClusterOK: corev1.ConditionTrue},
+	},
+	{
+		namespace: "b", name: "ready",
+		labels:     map[string]string{"env": "dev"},
+		addresses:  []string{"https://shared.example.com:443/"},
+		caBundle:   caBundle(testCertificates[0], testCertificates[1]),
+		user:       &v1alpha1.ObjectReference{Namespace: "a", Name: "creds"},
+		ctrl:       &v1alpha1.ObjectReference{Kind: "ConfigMap", Name: "other"},
+		conditions: map[v1alpha1.ClusterConditionType]corev1.ConditionStatus{v1alpha1.ClusterReady: corev1.ConditionTrue},
+	},
+	{
+		namespace: "b", name: "shared",
+		addresses: []string{"shared.example.com"},
+	},
+}
+
+// newIndexers returns an indexer with and an indexer without the
+// ClusterIndexers, both containing the testClusters.
+func newIndexers(t *testing.T) map[string]cache.Indexer {
+	indexers := map[string]cache.Indexer{
+		"indexed":   cache.NewIndexer(cache.MetaNamespaceKeyFunc, ClusterIndexers),
+		"unindexed": cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}),
+	}
+	for _, indexer := range indexers {
+		for _, tc := range testClusters {
+			if err := indexer.Add(tc.cluster()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+	}
+	return indexers
+}
+
+func keys(t *testing.T, clusters []*v1alpha1.Cluster, err error) []string {
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ret := []string{}
+	for _, cluster := range clusters {
+		ret = append(ret, cluster.Namespace+"/"+cluster.Name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func TestListByCondition(t *testing.T) {
+	for name, indexer := range newIndexers(t) {
+		t.Run(name, func(t *testing.T) {
+			lister := NewClusterLister(indexer)
+			nsLister := lister.Clusters("b")
+			prod, err := labels.Parse("env=prod")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			check := func(name string, want []string) func([]*v1alpha1.Cluster, error) {
+				return func(clusters []*v1alpha1.Cluster, err error) {
+					if got := keys(t, clusters, err); !reflect.DeepEqual(got, want) {
+						t.Errorf("%s: expected %v, got %v", name, want, got)
+					}
+				}
+			}
+
+			check("ready", []string{"a/ready", "b/ready"})(lister.ListReady())
+			check("ok", []string{"a/ok", "a/ready"})(lister.ListByCondition(v1alpha1.ClusterOK, corev1.ConditionTrue))
+			check("not ready", []string{"a/not-ready"})(lister.ListByCondition(v1alpha1.ClusterReady, corev1.ConditionFalse))
+			check("no such condition", []string{})(lister.ListByCondition(v1alpha1.ClusterDegraded, corev1.ConditionTrue))
+			check("selector", []string{"a/ready"})(lister.ListBySelectorAndCondition(prod, v1alpha1.ClusterReady, corev1.ConditionTrue))
+			check("namespace ready", []string{"b/ready"})(nsLister.ListReady())
+			check("namespace ok", []string{})(nsLister.ListByCondition(v1alpha1.ClusterOK, corev1.ConditionTrue))
+			check("namespace selector", []string{})(nsLister.ListBySelectorAndCondition(prod, v1alpha1.ClusterReady, corev1.ConditionTrue))
+		})
+	}
+}
+
+func TestGetByServerAddress(t *testing.T) {
+	for name, indexer := range newIndexers(t) {
+		t.Run(name, func(t *testing.T) {
+			lister := NewClusterLister(indexer)
+
+			tests := []struct {
+				name      string
+				namespace string
+				address   string
+				want      string
+				notFound  bool
+				ambiguous bool
+			}{
+				{name: "url", address: "https://ready.example.com:443", want: "a/ready"},
+				{name: "host", address: "ready.example.com", want: "a/ready"},
+				{name: "second endpoint", address: "https://10.0.0.1:6443", want: "a/ready"},
+				{name: "port", address: "not-ready.example.com:8443", want: "a/not-ready"},
+				{name: "wrong port", address: "not-ready.example.com", notFound: true},
+				{name: "ambiguous", address: "shared.example.com", ambiguous: true},
+				{name: "namespace", namespace: "a", address: "ready.example.com", want: "a/ready"},
+				{name: "other namespace", namespace: "b", address: "ready.example.com", notFound: true},
+				{name: "missing", address: "missing.example.com", notFound: true},
+			}
+
+			for _, tc := range tests {
+				var cluster *v1alpha1.Cluster
+				var err error
+				if tc.namespace == "" {
+					cluster, err = lister.GetByServerAddress(tc.address)
+				} else {
+					cluster, err = lister.Clusters(tc.namespace).GetByServerAddress(tc.address)
+				}
+				switch {
+				case tc.notFound:
+					if !apierrors.IsNotFound(err) {
+						t.Errorf("%s: expected a NotFound error, got %v", tc.name, err)
+					}
+				case tc.ambiguous:
+					if err == nil || apierrors.IsNotFound(err) {
+						t.Errorf("%s: expected an error for an ambiguous address, got %v", tc.name, err)
+					}
+				case err != nil:
+					t.Errorf("%s: unexpected error: %v", tc.name, err)
+				case cluster.Namespace+"/"+cluster.Name != tc.want:
+					t.Errorf("%s: expected %s, got %s/%s", tc.name, tc.want, cluster.Namespace, cluster.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestListReferencingSecret(t *testing.T) {
+	for name, indexer := range newIndexers(t) {
+		t.Run(name, func(t *testing.T) {
+			lister := NewClusterLister(indexer)
+
+			tests := []struct {
+				name      string
+				namespace string
+				secret    [2]string
+				want      []string
+			}{
+				{name: "default namespace", secret: [2]string{"a", "creds"}, want: []string{"a/ready", "b/ready"}},
+				{name: "explicit namespace", secret: [2]string{"secrets", "creds"}, want: []string{"a/not-ready"}},
+				{name: "not a secret", secret: [2]string{"b", "other"}, want: []string{}},
+				{name: "missing", secret: [2]string{"a", "missing"}, want: []string{}},
+				{name: "namespace", namespace: "b", secret: [2]string{"a", "creds"}, want: []string{"b/ready"}},
+			}
+
+			for _, tc := range tests {
+				var clusters []*v1alpha1.Cluster
+				var err error
+				if tc.namespace == "" {
+					clusters, err = lister.ListReferencingSecret(tc.secret[0], tc.secret[1])
+				} else {
+					clusters, err = lister.Clusters(tc.namespace).ListReferencingSecret(tc.secret[0], tc.secret[1])
+				}
+				if got := keys(t, clusters, err); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestListByServerHost(t *testing.T) {
+	for name, indexer := range newIndexers(t) {
+		t.Run(name, func(t *testing.T) {
+			lister := NewClusterLister(indexer)
+
+			tests := []struct {
+				name      string
+				namespace string
+				host      string
+				want      []string
+			}{
+				{name: "host", host: "ready.example.com", want: []string{"a/ready"}},
+				{name: "ip", host: "10.0.0.1", want: []string{"a/ready"}},
+				{name: "any port", host: "not-ready.example.com", want: []string{"a/not-ready"}},
+				{name: "case", host: "Shared.Example.com", want: []string{"b/ready", "b/shared"}},
+				{name: "missing", host: "missing.example.com", want: []string{}},
+				{name: "namespace", namespace: "a", host: "shared.example.com", want: []string{}},
+			}
+
+			for _, tc := range tests {
+				var clusters []*v1alpha1.Cluster
+				var err error
+				if tc.namespace == "" {
+					clusters, err = lister.ListByServerHost(tc.host)
+				} else {
+					clusters, err = lister.Clusters(tc.namespace).ListByServerHost(tc.host)
+				}
+				if got := keys(t, clusters, err); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestListByCABundleFingerprint(t *testing.T) {
+	for name, indexer := range newIndexers(t) {
+		t.Run(name, func(t *testing.T) {
+			lister := NewClusterLister(indexer)
+
+			tests := []struct {
+				name        string
+				namespace   string
+				fingerprint string
+				want        []string
+			}{
+				{name: "shared CA", fingerprint: fingerprint(testCertificates[0]), want: []string{"a/ready", "b/ready"}},
+				{name: "second certificate", fingerprint: fingerprint(testCertificates[1]), want: []string{"b/ready"}},
+				{name: "upper case", fingerprint: strings.ToUpper(fingerprint(testCertificates[1])), want: []string{"b/ready"}},
+				{name: "missing", fingerprint: fingerprint([]byte("other")), want: []string{}},
+				{name: "namespace", namespace: "a", fingerprint: fingerprint(testCertificates[0]), want: []string{"a/ready"}},
+			}
+
+			for _, tc := range tests {
+				var clusters []*v1alpha1.Cluster
+				var err error
+				if tc.namespace == "" {
+					clusters, err = lister.ListByCABundleFingerprint(tc.fingerprint)
+				} else {
+					clusters, err = lister.Clusters(tc.namespace).ListByCABundleFingerprint(tc.fingerprint)
+				}
+				if got := keys(t, clusters, err); !reflect.DeepEqual(got, tc.want) {
+					t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+				}
+			}
+		})
+	}
+}
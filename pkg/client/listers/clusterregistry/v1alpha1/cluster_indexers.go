@@ -0,0 +1,201 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+)
+
+// Names of the indexes used by the ClusterLister and ClusterNamespaceLister
+// queries.
+const (
+	// ServerAddressIndex indexes Clusters by the server address of each of
+	// their endpoints, normalized by v1alpha1.NormalizeServerAddress.
+	ServerAddressIndex = "clusterregistry.k8s.io/serverAddress"
+
+	// ServerHostIndex indexes Clusters by the lowercase host, without the
+	// port, of each of their endpoints.
+	ServerHostIndex = "clusterregistry.k8s.io/serverHost"
+
+	// CABundleIndex indexes Clusters by the CABundleFingerprints of their
+	// CA bundle.
+	CABundleIndex = "clusterregistry.k8s.io/caBundle"
+
+	// SecretReferenceIndex indexes Clusters by the namespace/name of each
+	// Secret referenced by their AuthInfo.
+	SecretReferenceIndex = "clusterregistry.k8s.io/secretReference"
+
+	// ConditionIndex indexes Clusters by the type=status of each of their
+	// conditions.
+	ConditionIndex = "clusterregistry.k8s.io/condition"
+)
+
+// ClusterIndexers are the indexes used by the ClusterLister and
+// ClusterNamespaceLister queries. The IndexedClusterInformer function of the
+// externalversions informers package adds them to the shared Cluster
+// informer. The queries return the same results without the indexes, but then
+// scan every Cluster in the cache.
+var ClusterIndexers = cache.Indexers{
+	ServerAddressIndex:   ServerAddressIndexFunc,
+	ServerHostIndex:      ServerHostIndexFunc,
+	CABundleIndex:        CABundleIndexFunc,
+	SecretReferenceIndex: SecretReferenceIndexFunc,
+	ConditionIndex:       ConditionIndexFunc,
+}
+
+// ServerAddressIndexFunc is the cache.IndexFunc of ServerAddressIndex.
+func ServerAddressIndexFunc(obj interface{}) ([]string, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, errors.Errorf("expected a Cluster, got %T", obj)
+	}
+	return serverAddresses(cluster), nil
+}
+
+// ServerHostIndexFunc is the cache.IndexFunc of ServerHostIndex.
+func ServerHostIndexFunc(obj interface{}) ([]string, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, errors.Errorf("expected a Cluster, got %T", obj)
+	}
+	return serverHosts(cluster), nil
+}
+
+// CABundleIndexFunc is the cache.IndexFunc of CABundleIndex.
+func CABundleIndexFunc(obj interface{}) ([]string, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, errors.Errorf("expected a Cluster, got %T", obj)
+	}
+	return CABundleFingerprints(cluster.Spec.KubernetesAPIEndpoints.CABundle), nil
+}
+
+// SecretReferenceIndexFunc is the cache.IndexFunc of SecretReferenceIndex.
+func SecretReferenceIndexFunc(obj interface{}) ([]string, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, errors.Errorf("expected a Cluster, got %T", obj)
+	}
+	return secretReferences(cluster), nil
+}
+
+// ConditionIndexFunc is the cache.IndexFunc of ConditionIndex.
+func ConditionIndexFunc(obj interface{}) ([]string, error) {
+	cluster, ok := obj.(*v1alpha1.Cluster)
+	if !ok {
+		return nil, errors.Errorf("expected a Cluster, got %T", obj)
+	}
+	values := make([]string, 0, len(cluster.Status.Conditions))
+	for _, condition := range cluster.Status.Conditions {
+		values = append(values, conditionValue(condition.Type, condition.Status))
+	}
+	return values, nil
+}
+
+// serverAddresses returns the distinct normalized server addresses of the
+// endpoints of cluster.
+func serverAddresses(cluster *v1alpha1.Cluster) []string {
+	var addresses []string
+	seen := map[string]bool{}
+	for _, endpoint := range cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints {
+		address := v1alpha1.NormalizeServerAddress(endpoint.ServerAddress)
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// serverHosts returns the distinct lowercase hosts of the endpoints of
+// cluster.
+func serverHosts(cluster *v1alpha1.Cluster) []string {
+	var hosts []string
+	seen := map[string]bool{}
+	for _, endpoint := range cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints {
+		u, err := helper.ServerURL(endpoint.ServerAddress)
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// CABundleFingerprints returns the distinct SHA-256 fingerprints, as
+// lowercase hex without separators, of the certificates in caBundle, a
+// PEM-encoded CA bundle. Other PEM blocks and data that is not PEM-encoded
+// are ignored.
+func CABundleFingerprints(caBundle []byte) []string {
+	var fingerprints []string
+	seen := map[string]bool{}
+	for {
+		var block *pem.Block
+		block, caBundle = pem.Decode(caBundle)
+		if block == nil {
+			return fingerprints
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		sum := sha256.Sum256(block.Bytes)
+		fingerprint := hex.EncodeToString(sum[:])
+		if !seen[fingerprint] {
+			seen[fingerprint] = true
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+}
+
+// secretReferences returns the distinct namespace/name keys of the Secrets
+// referenced by the AuthInfo of cluster. References without a kind refer to
+// Secrets, and references without a namespace to the namespace of cluster.
+func secretReferences(cluster *v1alpha1.Cluster) []string {
+	var keys []string
+	for _, ref := range []*v1alpha1.ObjectReference{cluster.Spec.AuthInfo.User, cluster.Spec.AuthInfo.Controller} {
+		if ref == nil || ref.Name == "" || (ref.Kind != "" && ref.Kind != "Secret") {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		if key := namespace + "/" + ref.Name; len(keys) == 0 || keys[0] != key {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func conditionValue(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus) string {
+	return string(conditionType) + "=" + string(status)
+}
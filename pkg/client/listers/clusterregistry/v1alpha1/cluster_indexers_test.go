@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/pem"
+	"reflect"
+	"sort"
+	"testing"
+
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+func TestIndexFuncs(t *testing.T) {
+	cluster := testClusters[0].cluster()
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = append(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints,
+		v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "10.0.0.0/8", ServerAddress: "ready.example.com:443"})
+	cluster.Spec.AuthInfo.Controller = &v1alpha1.ObjectReference{Namespace: "a", Name: "creds"}
+
+	tests := []struct {
+		name      string
+		indexFunc cache.IndexFunc
+		want      []string
+	}{
+		{"server address", ServerAddressIndexFunc, []string{"10.0.0.1:6443", "ready.example.com:443"}},
+		{"server host", ServerHostIndexFunc, []string{"10.0.0.1", "ready.example.com"}},
+		{"ca bundle", CABundleIndexFunc, []string{fingerprint(testCertificates[0])}},
+		{"secret reference", SecretReferenceIndexFunc, []string{"a/creds"}},
+		{"condition", ConditionIndexFunc, []string{"OK=True", "Ready=True"}},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.indexFunc(cluster)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+		if _, err := tc.indexFunc(&v1alpha1.ClusterList{}); err == nil {
+			t.Errorf("%s: expected an error for an object that is not a Cluster", tc.name)
+		}
+	}
+}
+
+func TestCABundleFingerprints(t *testing.T) {
+	bundle := caBundle(testCertificates[0], testCertificates[1], testCertificates[0])
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})...)
+	bundle = append(bundle, []byte("trailing garbage")...)
+
+	want := []string{fingerprint(testCertificates[0]), fingerprint(testCertificates[1])}
+	if got := CABundleFingerprints(bundle); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := CABundleFingerprints([]byte("not PEM")); len(got) != 0 {
+		t.Errorf("Expected no fingerprints, got %v", got)
+	}
+}
@@ -17,11 +17,3 @@ limitations under the License.
 // Code generated by lister-gen. DO NOT EDIT.
 
 package v1alpha1
-
-// ClusterListerExpansion allows custom methods to be added to
-// ClusterLister.
-type ClusterListerExpansion interface{}
-
-// ClusterNamespaceListerExpansion allows custom methods to be added to
-// ClusterNamespaceLister.
-type ClusterNamespaceListerExpansion interface{}
@@ -0,0 +1,202 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterclient builds clients for the clusters in the cluster
+// registry from their Cluster objects.
+package clusterclient
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+)
+
+// SecretTokenKey is the key of the bearer token in a Secret referenced by
+// AuthInfo.Controller. It matches the key used in service account token
+// Secrets.
+const SecretTokenKey = corev1.ServiceAccountTokenKey
+
+// ConfigErrorReason identifies the piece of information that was missing or
+// invalid when building a rest.Config for a cluster.
+type ConfigErrorReason string
+
+const (
+	// ReasonNoServerAddress means that no server address of the cluster matches
+	// the client.
+	ReasonNoServerAddress ConfigErrorReason = "NoServerAddress"
+
+	// ReasonInvalidServerAddress means that the selected server address of the
+	// cluster could not be parsed.
+	ReasonInvalidServerAddress ConfigErrorReason = "InvalidServerAddress"
+
+	// ReasonInvalidCABundle means that the CABundle of the cluster contains no
+	// valid PEM-encoded certificates.
+	ReasonInvalidCABundle ConfigErrorReason = "InvalidCABundle"
+
+	// ReasonNoControllerAuthInfo means that the cluster has no
+	// AuthInfo.Controller reference.
+	ReasonNoControllerAuthInfo ConfigErrorReason = "NoControllerAuthInfo"
+
+	// ReasonUnsupportedAuthInfoKind means that AuthInfo.Controller references
+	// an object that is not a Secret.
+	ReasonUnsupportedAuthInfoKind ConfigErrorReason = "UnsupportedAuthInfoKind"
+
+	// ReasonSecretNotFound means that the Secret referenced by
+	// AuthInfo.Controller does not exist.
+	ReasonSecretNotFound ConfigErrorReason = "SecretNotFound"
+
+	// ReasonSecretUnavailable means that the Secret referenced by
+	// AuthInfo.Controller could not be read.
+	ReasonSecretUnavailable ConfigErrorReason = "SecretUnavailable"
+
+	// ReasonNoCredentials means that the Secret referenced by
+	// AuthInfo.Controller contains neither a bearer token nor a client
+	// certificate and key.
+	ReasonNoCredentials ConfigErrorReason = "NoCredentials"
+)
+
+// ConfigError is returned when a rest.Config cannot be built for a cluster.
+type ConfigError struct {
+	// Cluster is the namespace/name of the cluster.
+	Cluster string
+	// Reason identifies what was missing or invalid.
+	Reason ConfigErrorReason
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("cannot build client config for cluster %s: %s", e.Cluster, e.Reason)
+	}
+	return fmt.Sprintf("cannot build client config for cluster %s: %s: %v", e.Cluster, e.Reason, e.Err)
+}
+
+// ReasonForError returns the reason for err if it is a *ConfigError, and an
+// empty reason otherwise.
+func ReasonForError(err error) ConfigErrorReason {
+	if configErr, ok := err.(*ConfigError); ok {
+		return configErr.Reason
+	}
+	return ""
+}
+
+// RESTConfigForCluster returns a rest.Config that a controller can use to
+// talk to the Kubernetes API server of cluster.
+//
+// The server address is selected from the cluster's server endpoints by
+// matching clientIP against their ClientCIDRs, or the addresses of the local
+// host if clientIP is nil. The CABundle of the cluster is used to verify the
+// server, or the system roots if it is empty. Credentials are read through
+// kubeClient from the Secret referenced by AuthInfo.Controller, which must
+// contain either a bearer token under the "token" key, or a client
+// certificate and key under the "tls.crt" and "tls.key" keys. The Secret is
+// looked up in the cluster's namespace if the reference has no namespace.
+//
+// Errors are returned as a *ConfigError.
+func RESTConfigForCluster(cluster *v1alpha1.Cluster, kubeClient kubernetes.Interface, clientIP net.IP) (*rest.Config, error) {
+	return restConfigForCluster(cluster, func(namespace, name string) (*corev1.Secret, error) {
+		return kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	}, clientIP)
+}
+
+// secretGetter returns the Secret with the given namespace and name, or a
+// NotFound error if it does not exist.
+type secretGetter func(namespace, name string) (*corev1.Secret, error)
+
+// restConfigForCluster implements RESTConfigForCluster, reading Secrets with
+// getSecret.
+func restConfigForCluster(cluster *v1alpha1.Cluster, getSecret secretGetter, clientIP net.IP) (*rest.Config, error) {
+	clusterKey := cluster.Namespace + "/" + cluster.Name
+	configError := func(reason ConfigErrorReason, err error) error {
+		return &ConfigError{Cluster: clusterKey, Reason: reason, Err: err}
+	}
+
+	address, err := helper.ServerAddressForClient(cluster, clientIP)
+	if err != nil {
+		return nil, configError(ReasonNoServerAddress, err)
+	}
+	serverURL, err := helper.ServerURL(address)
+	if err != nil {
+		return nil, configError(ReasonInvalidServerAddress, err)
+	}
+
+	config := &rest.Config{Host: serverURL.String()}
+
+	if caBundle := cluster.Spec.KubernetesAPIEndpoints.CABundle; len(caBundle) > 0 {
+		if !x509.NewCertPool().AppendCertsFromPEM(caBundle) {
+			return nil, configError(ReasonInvalidCABundle, nil)
+		}
+		config.TLSClientConfig.CAData = caBundle
+	}
+
+	ref := cluster.Spec.AuthInfo.Controller
+	if ref == nil {
+		return nil, configError(ReasonNoControllerAuthInfo, nil)
+	}
+	if ref.Kind != "" && ref.Kind != "Secret" {
+		return nil, configError(ReasonUnsupportedAuthInfoKind, errors.Errorf("kind %q is not supported", ref.Kind))
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cluster.Namespace
+	}
+	secret, err := getSecret(namespace, ref.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, configError(ReasonSecretNotFound, err)
+	} else if err != nil {
+		return nil, configError(ReasonSecretUnavailable, err)
+	}
+
+	if err := setCredentials(config, secret); err != nil {
+		return nil, configError(ReasonNoCredentials, err)
+	}
+	return config, nil
+}
+
+// setCredentials sets the credentials of config from secret, preferring a
+// bearer token over a client certificate if the secret contains both.
+func setCredentials(config *rest.Config, secret *corev1.Secret) error {
+	if token := secret.Data[SecretTokenKey]; len(token) > 0 {
+		config.BearerToken = string(token)
+		return nil
+	}
+
+	cert, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	switch {
+	case len(cert) > 0 && len(key) > 0:
+		config.TLSClientConfig.CertData = cert
+		config.TLSClientConfig.KeyData = key
+		return nil
+	case len(cert) > 0:
+		return errors.Errorf("secret %s/%s has a %q but no %q", secret.Namespace, secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	case len(key) > 0:
+		return errors.Errorf("secret %s/%s has a %q but no %q", secret.Namespace, secret.Name, corev1.TLSPrivateKeyKey, corev1.TLSCertKey)
+	}
+	return errors.Errorf("secret %s/%s has neither a %q nor a %q and %q", secret.Namespace, secret.Name, SecretTokenKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+// testCA is used as the CABundle of the test clusters.
+var testCA = newTestCA()
+
+// newTestCA returns a new self-signed PEM-encoded certificate, used as a
+// CABundle in tests.
+func newTestCA() []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newTestCluster(controller *v1alpha1.ObjectReference) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "registry"},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesAPIEndpoints: v1alpha1.KubernetesAPIEndpoints{
+				ServerEndpoints: []v1alpha1.ServerAddressByClientCIDR{
+					{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1:6443"},
+					{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com"},
+				},
+				CABundle: testCA,
+			},
+			AuthInfo: v1alpha1.AuthInfo{Controller: controller},
+		},
+	}
+}
+
+func newSecret(namespace, name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string][]byte{},
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func TestRESTConfigForCluster(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(
+		newSecret("registry", "token", map[string]string{"token": "abc"}),
+		newSecret("other", "cert", map[string]string{"tls.crt": "cert", "tls.key": "key"}),
+	)
+
+	t.Run("token", func(t *testing.T) {
+		cluster := newTestCluster(&v1alpha1.ObjectReference{Kind: "Secret", Name: "token"})
+		config, err := RESTConfigForCluster(cluster, kubeClient, net.ParseIP("10.1.2.3"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.Host != "https://10.0.0.1:6443" {
+			t.Errorf("Expected host %q, got %q", "https://10.0.0.1:6443", config.Host)
+		}
+		if string(config.TLSClientConfig.CAData) != string(testCA) {
+			t.Errorf("Expected the CABundle as CA data, got %q", config.TLSClientConfig.CAData)
+		}
+		if config.BearerToken != "abc" {
+			t.Errorf("Expected bearer token %q, got %q", "abc", config.BearerToken)
+		}
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "cert", Namespace: "other"})
+		config, err := RESTConfigForCluster(cluster, kubeClient, net.ParseIP("192.168.0.1"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.Host != "https://cluster.example.com" {
+			t.Errorf("Expected host %q, got %q", "https://cluster.example.com", config.Host)
+		}
+		if string(config.TLSClientConfig.CertData) != "cert" || string(config.TLSClientConfig.KeyData) != "key" {
+			t.Errorf("Expected client certificate and key from the secret, got %q and %q",
+				config.TLSClientConfig.CertData, config.TLSClientConfig.KeyData)
+		}
+	})
+}
+
+func TestRESTConfigForClusterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster func() *v1alpha1.Cluster
+		objects []runtime.Object
+		reason  ConfigErrorReason
+	}{
+		{
+			name: "no server endpoints",
+			cluster: func() *v1alpha1.Cluster {
+				cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+				cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = nil
+				return cluster
+			},
+			reason: ReasonNoServerAddress,
+		},
+		{
+			name: "invalid server address",
+			cluster: func() *v1alpha1.Cluster {
+				cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+				cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints[1].ServerAddress = "https://"
+				return cluster
+			},
+			reason: ReasonInvalidServerAddress,
+		},
+		{
+			name: "invalid CA bundle",
+			cluster: func() *v1alpha1.Cluster {
+				cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+				cluster.Spec.KubernetesAPIEndpoints.CABundle = []byte("garbage")
+				return cluster
+			},
+			reason: ReasonInvalidCABundle,
+		},
+		{
+			name:    "no controller auth info",
+			cluster: func() *v1alpha1.Cluster { return newTestCluster(nil) },
+			reason:  ReasonNoControllerAuthInfo,
+		},
+		{
+			name: "unsupported kind",
+			cluster: func() *v1alpha1.Cluster {
+				return newTestCluster(&v1alpha1.ObjectReference{Kind: "ConfigMap", Name: "token"})
+			},
+			reason: ReasonUnsupportedAuthInfoKind,
+		},
+		{
+			name: "secret not found",
+			cluster: func() *v1alpha1.Cluster {
+				return newTestCluster(&v1alpha1.ObjectReference{Name: "missing"})
+			},
+			reason: ReasonSecretNotFound,
+		},
+		{
+			name: "no credentials",
+			cluster: func() *v1alpha1.Cluster {
+				return newTestCluster(&v1alpha1.ObjectReference{Name: "empty"})
+			},
+			objects: []runtime.Object{newSecret("registry", "empty", map[string]string{"tls.crt": "cert"})},
+			reason:  ReasonNoCredentials,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset(tc.objects...)
+			_, err := RESTConfigForCluster(tc.cluster(), kubeClient, net.ParseIP("192.168.0.1"))
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+			if reason := ReasonForError(err); reason != tc.reason {
+				t.Errorf("Expected reason %q, got %q (%v)", tc.reason, reason, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclient
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1alpha1"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+)
+
+// ClientPool caches clients for the clusters in the cluster registry, keyed
+// by the namespace and name of their Cluster objects. Clients are built
+// lazily on first use with RESTConfigForCluster, shared by all users of the
+// pool, and discarded when the spec of their Cluster or the Secret referenced
+// by its AuthInfo.Controller changes, or when the Cluster is deleted.
+//
+// A ClientPool is safe for concurrent use. Building the clients of a cluster
+// does not block the users of the other clusters, and concurrent users of
+// the same cluster share a single build.
+type ClientPool struct {
+	// getSecret reads the Secrets referenced by Clusters.
+	getSecret secretGetter
+
+	clusterLister listers.ClusterLister
+
+	// clientIP is used to select the server address of each cluster. If nil,
+	// the addresses of the local host are used.
+	clientIP net.IP
+
+	// lock guards entries, builds and the generations of the builds. It is
+	// not held while an entry is being built.
+	lock    sync.Mutex
+	entries map[string]*poolEntry
+
+	// builds are the entries being built, by key.
+	builds map[string]*poolBuild
+}
+
+// poolEntry holds the config and clients of a single cluster.
+type poolEntry struct {
+	config *rest.Config
+	// secretKey is the namespace/name of the Secret from which the
+	// credentials in config were read.
+	secretKey string
+
+	kubeClient    kubernetes.Interface
+	dynamicClient dynamic.Interface
+}
+
+// poolBuild is an entry being built. done is closed once entry or err is set.
+type poolBuild struct {
+	done  chan struct{}
+	entry *poolEntry
+	err   error
+
+	// generation is incremented when the entry is invalidated while it is
+	// being built. The entry is only cached if its generation is still the
+	// one its build started with, since it may have been built from the
+	// state that was invalidated.
+	generation uint64
+}
+
+// NewClientPool returns a ClientPool for the Clusters known to
+// clusterInformer. Entries are invalidated by events from clusterInformer.
+// If secretInformer is not nil, Secrets are read from its cache, and entries
+// are also invalidated by changes to the Secrets it watches; otherwise
+// Secrets are read through kubeClient. The informers must be started by the
+// caller.
+func NewClientPool(
+	kubeClient kubernetes.Interface,
+	clusterInformer informers.ClusterInformer,
+	secretInformer coreinformers.SecretInformer,
+	clientIP net.IP) *ClientPool {
+
+	pool := &ClientPool{
+		getSecret: func(namespace, name string) (*corev1.Secret, error) {
+			return kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		},
+		clusterLister: clusterInformer.Lister(),
+		clientIP:      clientIP,
+		entries:       make(map[string]*poolEntry),
+		builds:        make(map[string]*poolBuild),
+	}
+	if secretInformer != nil {
+		secretLister := secretInformer.Lister()
+		pool.getSecret = func(namespace, name string) (*corev1.Secret, error) {
+			return secretLister.Secrets(namespace).Get(name)
+		}
+	}
+
+	clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: pool.clusterUpdated,
+		DeleteFunc: pool.clusterDeleted,
+	})
+	if secretInformer != nil {
+		secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: pool.secretUpdated,
+			DeleteFunc: pool.secretDeleted,
+		})
+	}
+
+	return pool
+}
+
+// Config returns a copy of the rest.Config of the cluster with the given
+// namespace and name.
+func (p *ClientPool) Config(namespace, name string) (*rest.Config, error) {
+	entry, err := p.entry(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return rest.CopyConfig(entry.config), nil
+}
+
+// KubernetesClient returns the Kubernetes clientset of the cluster with the
+// given namespace and name.
+func (p *ClientPool) KubernetesClient(namespace, name string) (kubernetes.Interface, error) {
+	entry, err := p.entry(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating a client does not contact the cluster.
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if entry.kubeClient == nil {
+		if entry.kubeClient, err = kubernetes.NewForConfig(entry.config); err != nil {
+			return nil, err
+		}
+	}
+	return entry.kubeClient, nil
+}
+
+// DynamicClient returns the dynamic client of the cluster with the given
+// namespace and name.
+func (p *ClientPool) DynamicClient(namespace, name string) (dynamic.Interface, error) {
+	entry, err := p.entry(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Creating a client does not contact the cluster.
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if entry.dynamicClient == nil {
+		if entry.dynamicClient, err = dynamic.NewForConfig(entry.config); err != nil {
+			return nil, err
+		}
+	}
+	return entry.dynamicClient, nil
+}
+
+// Invalidate discards the config and clients of the cluster with the given
+// namespace and name. They are rebuilt on next use.
+func (p *ClientPool) Invalidate(namespace, name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.invalidate(namespace + "/" + name)
+}
+
+// entry returns the entry of the cluster with the given namespace and name,
+// building its config if needed, or waiting for the build in progress.
+// Failures are not cached. p.lock must not be held.
+func (p *ClientPool) entry(namespace, name string) (*poolEntry, error) {
+	key := namespace + "/" + name
+
+	p.lock.Lock()
+	if entry, ok := p.entries[key]; ok {
+		p.lock.Unlock()
+		return entry, nil
+	}
+	if build, ok := p.builds[key]; ok {
+		p.lock.Unlock()
+		<-build.done
+		return build.entry, build.err
+	}
+	build := &poolBuild{done: make(chan struct{})}
+	p.builds[key] = build
+	generation := build.generation
+	p.lock.Unlock()
+
+	build.entry, build.err = p.build(namespace, name)
+
+	p.lock.Lock()
+	if p.builds[key] == build {
+		delete(p.builds, key)
+	}
+	if build.err == nil && build.generation == generation {
+		p.entries[key] = build.entry
+	}
+	p.lock.Unlock()
+	close(build.done)
+
+	return build.entry, build.err
+}
+
+// build builds the entry of the cluster with the given namespace and name.
+func (p *ClientPool) build(namespace, name string) (*poolEntry, error) {
+	cluster, err := p.clusterLister.Clusters(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	config, err := restConfigForCluster(cluster, p.getSecret, p.clientIP)
+	if err != nil {
+		return nil, err
+	}
+	return &poolEntry{config: config, secretKey: controllerSecretKey(cluster)}, nil
+}
+
+// invalidate discards the entry with the given key, and makes the build of
+// that entry in progress, if any, neither cached nor shared with later
+// users. p.lock must be held.
+func (p *ClientPool) invalidate(key string) {
+	if build, ok := p.builds[key]; ok {
+		build.generation++
+		delete(p.builds, key)
+	}
+	if _, ok := p.entries[key]; ok {
+		klog.V(4).Infof("Invalidating clients of cluster %s", key)
+		delete(p.entries, key)
+	}
+}
+
+func (p *ClientPool) clusterUpdated(oldObj, newObj interface{}) {
+	oldCluster, newCluster := oldObj.(*v1alpha1.Cluster), newObj.(*v1alpha1.Cluster)
+	if apiequality.Semantic.DeepEqual(oldCluster.Spec, newCluster.Spec) {
+		return
+	}
+	p.clusterDeleted(newObj)
+}
+
+// clusterDeleted invalidates the entry of a deleted or changed Cluster.
+func (p *ClientPool) clusterDeleted(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get key of %#v: %v", obj, err)
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.invalidate(key)
+}
+
+func (p *ClientPool) secretUpdated(oldObj, newObj interface{}) {
+	oldSecret, newSecret := oldObj.(*corev1.Secret), newObj.(*corev1.Secret)
+	if apiequality.Semantic.DeepEqual(oldSecret.Data, newSecret.Data) {
+		return
+	}
+	p.secretDeleted(newObj)
+}
+
+// secretDeleted invalidates the entries of all clusters whose credentials
+// were read from a deleted or changed Secret.
+func (p *ClientPool) secretDeleted(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get key of %#v: %v", obj, err)
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	// The Secrets read by the builds in progress are not known yet, so none
+	// of them is cached or shared with later users.
+	for clusterKey := range p.builds {
+		p.invalidate(clusterKey)
+	}
+	for clusterKey, entry := range p.entries {
+		if entry.secretKey == key {
+			p.invalidate(clusterKey)
+		}
+	}
+}
+
+// controllerSecretKey returns the namespace/name of the Secret referenced by
+// the AuthInfo.Controller of cluster, defaulting to the cluster's namespace
+// as RESTConfigForCluster does.
+func controllerSecretKey(cluster *v1alpha1.Cluster) string {
+	ref := cluster.Spec.AuthInfo.Controller
+	if ref == nil {
+		return ""
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cluster.Namespace
+	}
+	return namespace + "/" + ref.Name
+}
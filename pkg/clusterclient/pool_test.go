@@ -0,0 +1,243 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclient
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+	clusterinformers "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1alpha1"
+)
+
+func TestClientPool(t *testing.T) {
+	secret := newSecret("registry", "token", map[string]string{"token": "abc"})
+	cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+
+	kubeClient := kubefake.NewSimpleClientset(secret)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(cluster), 0)
+	clusterInformer := informerFactory.Clusterregistry().V1alpha1().Clusters()
+	if err := clusterInformer.Informer().GetIndexer().Add(cluster); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	secretInformer := kubeInformerFactory.Core().V1().Secrets()
+	if err := secretInformer.Informer().GetIndexer().Add(secret); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pool := NewClientPool(kubeClient, clusterInformer, secretInformer, net.ParseIP("10.0.0.2"))
+
+	first, err := pool.KubernetesClient("registry", "cluster")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if second, _ := pool.KubernetesClient("registry", "cluster"); second != first {
+		t.Errorf("Expected the client to be cached")
+	}
+	if _, err := pool.DynamicClient("registry", "cluster"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// A status-only update does not invalidate the entry.
+	updated := cluster.DeepCopy()
+	updated.Status.Platform = "linux/amd64"
+	pool.clusterUpdated(cluster, updated)
+	if client, _ := pool.KubernetesClient("registry", "cluster"); client != first {
+		t.Errorf("Expected the client to survive a status update")
+	}
+
+	// A spec update does.
+	updated.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ServerAddress = "10.0.0.100"
+	if err := clusterInformer.Informer().GetIndexer().Update(updated); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pool.clusterUpdated(cluster, updated)
+	client, _ := pool.KubernetesClient("registry", "cluster")
+	if client == first {
+		t.Errorf("Expected the client to be rebuilt after a spec update")
+	}
+	config, _ := pool.Config("registry", "cluster")
+	if config.Host != "https://10.0.0.100" {
+		t.Errorf("Expected host %q, got %q", "https://10.0.0.100", config.Host)
+	}
+
+	// So does a change to the referenced Secret.
+	updatedSecret := secret.DeepCopy()
+	updatedSecret.Data["token"] = []byte("def")
+	pool.secretUpdated(secret, updatedSecret)
+	if _, ok := pool.entries["registry/cluster"]; ok {
+		t.Errorf("Expected the entry to be invalidated after a secret update")
+	}
+
+	// And deleting the Cluster.
+	if _, err := pool.Config("registry", "cluster"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pool.clusterDeleted(updated)
+	if _, ok := pool.entries["registry/cluster"]; ok {
+		t.Errorf("Expected the entry to be invalidated after the cluster was deleted")
+	}
+
+	if _, err := pool.KubernetesClient("registry", "missing"); err == nil {
+		t.Errorf("Expected an error for a missing cluster")
+	}
+}
+
+func TestClientPoolReadsSecretsFromInformer(t *testing.T) {
+	secret := newSecret("registry", "token", map[string]string{"token": "abc"})
+	cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+
+	// The Secret is only in the cache of the informer.
+	kubeClient := kubefake.NewSimpleClientset()
+	secretInformer := kubeinformers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Secrets()
+	if err := secretInformer.Informer().GetIndexer().Add(secret); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pool := NewClientPool(kubeClient, newClusterInformer(t, cluster), secretInformer, net.ParseIP("10.0.0.2"))
+
+	config, err := pool.Config("registry", "cluster")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.BearerToken != "abc" {
+		t.Errorf("Expected the token of the Secret, got %q", config.BearerToken)
+	}
+}
+
+// blockingSecretGets makes the gets of the Secrets named after the keys of
+// gates through kubeClient signal that they started by closing the first
+// channel of the gate, and wait until its second channel is closed. It
+// returns the number of gets of each Secret.
+func blockingSecretGets(kubeClient *kubefake.Clientset, gates map[string][2]chan struct{}) map[string]*int32 {
+	gets := map[string]*int32{}
+	for name := range gates {
+		gets[name] = new(int32)
+	}
+	kubeClient.PrependReactor("get", "secrets", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		name := action.(clienttesting.GetAction).GetName()
+		if gate, ok := gates[name]; ok && atomic.AddInt32(gets[name], 1) == 1 {
+			close(gate[0])
+			<-gate[1]
+		}
+		return false, nil, nil
+	})
+	return gets
+}
+
+func TestClientPoolConcurrentBuilds(t *testing.T) {
+	cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+	slowCluster := newTestCluster(&v1alpha1.ObjectReference{Name: "slow"})
+	slowCluster.Name = "slow"
+
+	kubeClient := kubefake.NewSimpleClientset(
+		newSecret("registry", "token", map[string]string{"token": "abc"}),
+		newSecret("registry", "slow", map[string]string{"token": "def"}),
+	)
+	started, release := make(chan struct{}), make(chan struct{})
+	gets := blockingSecretGets(kubeClient, map[string][2]chan struct{}{"slow": {started, release}})
+	pool := NewClientPool(kubeClient, newClusterInformer(t, cluster, slowCluster), nil, net.ParseIP("10.0.0.2"))
+
+	if _, err := pool.Config("registry", "cluster"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := pool.Config("registry", "slow")
+			results <- err
+		}()
+	}
+	<-started
+
+	// The slow build blocks neither the cached clusters nor the
+	// invalidations.
+	done := make(chan error)
+	go func() {
+		_, err := pool.KubernetesClient("registry", "cluster")
+		pool.Invalidate("registry", "cluster")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the pool not to be blocked by the build of another cluster")
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	// The second user either shared the build or got the cached entry.
+	if n := atomic.LoadInt32(gets["slow"]); n != 1 {
+		t.Errorf("Expected the Secret to be read once, got %d", n)
+	}
+}
+
+func TestClientPoolInvalidationDuringBuild(t *testing.T) {
+	cluster := newTestCluster(&v1alpha1.ObjectReference{Name: "token"})
+	kubeClient := kubefake.NewSimpleClientset(newSecret("registry", "token", map[string]string{"token": "abc"}))
+	started, release := make(chan struct{}), make(chan struct{})
+	blockingSecretGets(kubeClient, map[string][2]chan struct{}{"token": {started, release}})
+	pool := NewClientPool(kubeClient, newClusterInformer(t, cluster), nil, net.ParseIP("10.0.0.2"))
+
+	result := make(chan error)
+	go func() {
+		_, err := pool.Config("registry", "cluster")
+		result <- err
+	}()
+	<-started
+	pool.Invalidate("registry", "cluster")
+	close(release)
+	if err := <-result; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	pool.lock.Lock()
+	_, cached := pool.entries["registry/cluster"]
+	pool.lock.Unlock()
+	if cached {
+		t.Errorf("Expected a build overtaken by an invalidation not to be cached")
+	}
+}
+
+// newClusterInformer returns a Cluster informer whose cache holds clusters.
+func newClusterInformer(t *testing.T, clusters ...*v1alpha1.Cluster) clusterinformers.ClusterInformer {
+	informer := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0).Clusterregistry().V1alpha1().Clusters()
+	for _, cluster := range clusters {
+		if err := informer.Informer().GetIndexer().Add(cluster); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	return informer
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterstatus contains a controller that probes the API servers of
+// the clusters in the registry and reports their health in the ClusterOK
+// condition.
+//
+// The controller also reports what it observed while probing in the
+// ClusterReachable, ClusterCertificateValid and ClusterAuthenticated
+// conditions, and aggregates them into the ClusterReady condition. The probes
+// are anonymous, so ClusterAuthenticated is False only if the API server
+// rejects them with 401 Unauthorized.
+package clusterstatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/controller"
+)
+
+const (
+	// DefaultProbeInterval is the default interval between two probes of the
+	// same cluster.
+	DefaultProbeInterval = 30 * time.Second
+
+	// DefaultProbeTimeout is the default timeout of a single HTTP request made
+	// while probing a cluster.
+	DefaultProbeTimeout = 10 * time.Second
+
+	// DefaultHeartbeatGranularity is the default minimum interval between two
+	// status writes for a cluster whose conditions have not changed.
+	DefaultHeartbeatGranularity = 5 * time.Minute
+)
+
+// Reasons used in the conditions set by this controller.
+const (
+	// ReasonHealthy means that an API server endpoint of the cluster responded
+	// successfully to its health checks.
+	ReasonHealthy = "Healthy"
+
+	// ReasonUnhealthy means that at least one API server endpoint of the
+	// cluster responded, but none responded successfully to its health checks.
+	ReasonUnhealthy = "Unhealthy"
+
+	// ReasonUnreachable means that none of the API server endpoints of the
+	// cluster could be reached.
+	ReasonUnreachable = "Unreachable"
+
+	// ReasonNoServerEndpoints means that the cluster has no API server
+	// endpoints to probe.
+	ReasonNoServerEndpoints = "NoServerEndpoints"
+
+	// ReasonInvalidCABundle means that the CABundle of the cluster could not
+	// be parsed.
+	ReasonInvalidCABundle = "InvalidCABundle"
+
+	// ReasonResponded means that an API server endpoint of the cluster
+	// responded to the probes.
+	ReasonResponded = "Responded"
+
+	// ReasonCertificateVerified means that the serving certificate of an API
+	// server endpoint of the cluster is trusted by its CABundle.
+	ReasonCertificateVerified = "CertificateVerified"
+
+	// ReasonCertificateInvalid means that at least one API server endpoint of
+	// the cluster could be reached, but none served a certificate trusted by
+	// its CABundle.
+	ReasonCertificateInvalid = "CertificateInvalid"
+
+	// ReasonAccepted means that an API server endpoint of the cluster accepted
+	// the probes.
+	ReasonAccepted = "Accepted"
+
+	// ReasonUnauthorized means that the API server endpoints of the cluster
+	// that could be verified rejected the probes with 401 Unauthorized.
+	ReasonUnauthorized = "Unauthorized"
+)
+
+// Controller probes the API server endpoints of each Cluster in the registry
+// and records the outcome in the ClusterOK, ClusterReachable,
+// ClusterCertificateValid, ClusterAuthenticated and ClusterReady conditions of
+// its status. Each
+// Cluster is probed again after probeInterval, so that its status is
+// refreshed periodically.
+type Controller struct {
+	*controller.Controller
+
+	// clusterregistryclientset is a clientset for our own API group
+	clusterregistryclientset clientset.Interface
+
+	clusterLister listers.ClusterLister
+
+	// probeInterval is the interval between two probes of the same cluster.
+	probeInterval time.Duration
+
+	// probeTimeout is the timeout of a single HTTP request made while probing.
+	probeTimeout time.Duration
+
+	// heartbeatGranularity is the minimum interval between two writes of the
+	// LastHeartbeatTime of an otherwise unchanged condition.
+	heartbeatGranularity time.Duration
+
+	// now returns the current time. It is overridden in tests.
+	now func() metav1.Time
+}
+
+// NewController returns a new cluster status controller.
+func NewController(
+	clusterregistryclientset clientset.Interface,
+	clusterregistryInformerFactory informers.SharedInformerFactory,
+	probeInterval time.Duration,
+	probeTimeout time.Duration,
+	heartbeatGranularity time.Duration) *Controller {
+
+	clusterInformer := clusterregistryInformerFactory.Clusterregistry().V1alpha1().Clusters()
+
+	c := &Controller{
+		clusterregistryclientset: clusterregistryclientset,
+		clusterLister:            clusterInformer.Lister(),
+		probeInterval:            probeInterval,
+		probeTimeout:             probeTimeout,
+		heartbeatGranularity:     heartbeatGranularity,
+		now:                      metav1.Now,
+	}
+	c.Controller = controller.New("ClusterStatus", c.syncHandler, controller.Options{})
+	// Status updates, including the ones made by this controller, do not
+	// warrant an immediate probe; the cluster will be probed again after
+	// probeInterval anyway.
+	c.WatchClusters(clusterInformer, controller.SpecChanged)
+
+	return c
+}
+
+// syncHandler probes the cluster identified by key, writes the result to its
+// conditions and schedules the next probe. The status is only written if a
+// condition changed or its heartbeat is older than heartbeatGranularity.
+func (c *Controller) syncHandler(key string) (controller.Result, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(errors.Errorf("invalid resource key: %s", key))
+		return controller.Result{}, nil
+	}
+
+	cluster, err := c.clusterLister.Clusters(namespace).Get(name)
+	if err != nil {
+		// The Cluster may have been deleted, in which case there is nothing
+		// left to probe.
+		if apierrors.IsNotFound(err) {
+			return controller.Result{}, nil
+		}
+		return controller.Result{}, err
+	}
+
+	conditions := c.probeCluster(cluster)
+
+	cluster = cluster.DeepCopy()
+	now := c.now()
+	changed := false
+	for _, condition := range conditions {
+		condition.LastHeartbeatTime = now
+		if helper.SetClusterCondition(&cluster.Status, condition, c.heartbeatGranularity) {
+			changed = true
+		}
+	}
+	ready := helper.AggregateReadyCondition(&cluster.Status)
+	ready.LastHeartbeatTime = now
+	if helper.SetClusterCondition(&cluster.Status, ready, c.heartbeatGranularity) {
+		changed = true
+	}
+	if changed {
+		if err := c.updateStatus(cluster); err != nil {
+			return controller.Result{}, err
+		}
+	}
+
+	return controller.Result{RequeueAfter: c.probeInterval}, nil
+}
+
+// probeCluster probes each of the API server endpoints of cluster in turn,
+// and returns the resulting ClusterOK, ClusterReachable,
+// ClusterCertificateValid and ClusterAuthenticated conditions, without
+// timestamps. The cluster is considered healthy as soon as one of its
+// endpoints passes its health checks; the other conditions reflect the
+// endpoint whose probe got the furthest.
+func (c *Controller) probeCluster(cluster *v1alpha1.Cluster) []v1alpha1.ClusterCondition {
+	endpoints := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints
+	if len(endpoints) == 0 {
+		message := "The cluster has no server endpoints to probe."
+		return []v1alpha1.ClusterCondition{
+			newCondition(v1alpha1.ClusterOK, corev1.ConditionUnknown, ReasonNoServerEndpoints, message),
+			newCondition(v1alpha1.ClusterReachable, corev1.ConditionUnknown, ReasonNoServerEndpoints, message),
+			newCondition(v1alpha1.ClusterCertificateValid, corev1.ConditionUnknown, ReasonNoServerEndpoints, message),
+			newCondition(v1alpha1.ClusterAuthenticated, corev1.ConditionUnknown, ReasonNoServerEndpoints, message),
+		}
+	}
+
+	client, err := newProbeClient(cluster.Spec.KubernetesAPIEndpoints.CABundle, c.probeTimeout)
+	if err != nil {
+		// No serving certificate can be trusted by an invalid CABundle.
+		return []v1alpha1.ClusterCondition{
+			newCondition(v1alpha1.ClusterOK, corev1.ConditionUnknown, ReasonInvalidCABundle, err.Error()),
+			newCondition(v1alpha1.ClusterReachable, corev1.ConditionUnknown, ReasonInvalidCABundle, err.Error()),
+			newCondition(v1alpha1.ClusterCertificateValid, corev1.ConditionFalse, ReasonInvalidCABundle, err.Error()),
+			newCondition(v1alpha1.ClusterAuthenticated, corev1.ConditionUnknown, ReasonInvalidCABundle, err.Error()),
+		}
+	}
+	if transport, ok := client.Transport.(interface{ CloseIdleConnections() }); ok {
+		defer transport.CloseIdleConnections()
+	}
+
+	ok := newCondition(v1alpha1.ClusterOK, corev1.ConditionFalse, ReasonUnreachable, "")
+	stage, address := stageUnreachable, ""
+	var failures []string
+	for _, endpoint := range endpoints {
+		base, err := helper.ServerURL(endpoint.ServerAddress)
+		if err == nil {
+			err = probeEndpoint(client, base)
+		}
+		if s := probeStageOf(err); s > stage || address == "" {
+			stage, address = s, endpoint.ServerAddress
+		}
+		if err == nil {
+			ok.Status = corev1.ConditionTrue
+			ok.Reason = ReasonHealthy
+			ok.Message = fmt.Sprintf("The API server at %s is healthy.", endpoint.ServerAddress)
+			break
+		}
+		if _, isStatusErr := err.(*httpStatusError); isStatusErr {
+			ok.Reason = ReasonUnhealthy
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", endpoint.ServerAddress, err))
+	}
+	failed := strings.Join(failures, "; ")
+	if ok.Status != corev1.ConditionTrue {
+		ok.Message = failed
+	}
+
+	reachable := newCondition(v1alpha1.ClusterReachable, corev1.ConditionFalse, ReasonUnreachable, failed)
+	certificateValid := newCondition(v1alpha1.ClusterCertificateValid, corev1.ConditionUnknown, ReasonUnreachable, failed)
+	authenticated := newCondition(v1alpha1.ClusterAuthenticated, corev1.ConditionUnknown, ReasonUnreachable, failed)
+	if stage >= stageConnected {
+		reachable = newCondition(v1alpha1.ClusterReachable, corev1.ConditionTrue, ReasonResponded,
+			fmt.Sprintf("The API server at %s responded.", address))
+		certificateValid = newCondition(v1alpha1.ClusterCertificateValid, corev1.ConditionFalse, ReasonCertificateInvalid, failed)
+		authenticated.Reason = ReasonCertificateInvalid
+	}
+	if stage >= stageVerified {
+		certificateValid = newCondition(v1alpha1.ClusterCertificateValid, corev1.ConditionTrue, ReasonCertificateVerified,
+			fmt.Sprintf("The serving certificate of the API server at %s is trusted.", address))
+		authenticated = newCondition(v1alpha1.ClusterAuthenticated, corev1.ConditionFalse, ReasonUnauthorized, failed)
+	}
+	if stage >= stageAccepted {
+		authenticated = newCondition(v1alpha1.ClusterAuthenticated, corev1.ConditionTrue, ReasonAccepted,
+			fmt.Sprintf("The API server at %s accepted the probes.", address))
+	}
+	return []v1alpha1.ClusterCondition{ok, reachable, certificateValid, authenticated}
+}
+
+// newCondition returns a condition without timestamps.
+func newCondition(conditionType v1alpha1.ClusterConditionType, status corev1.ConditionStatus, reason, message string) v1alpha1.ClusterCondition {
+	return v1alpha1.ClusterCondition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// updateStatus writes the status of cluster to the API server.
+func (c *Controller) updateStatus(cluster *v1alpha1.Cluster) error {
+	_, err := c.clusterregistryclientset.ClusterregistryV1alpha1().Clusters(cluster.Namespace).UpdateStatus(context.TODO(), cluster, metav1.UpdateOptions{})
+	return err
+}
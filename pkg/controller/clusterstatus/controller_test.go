@@ -0,0 +1,272 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterstatus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+)
+
+// newTestCA returns a new self-signed PEM-encoded certificate. It did not sign
+// the certificates of the test API servers, so it does not trust them.
+func newTestCA() []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+const testNamespace = "default"
+
+// newAPIServer returns a TLS server that responds to /healthz and /readyz
+// with the given status codes, along with a PEM-encoded CA bundle that
+// trusts it.
+func newAPIServer(healthz, readyz int) (*httptest.Server, []byte) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(healthz)
+		w.Write([]byte(http.StatusText(healthz)))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(readyz)
+		w.Write([]byte(http.StatusText(readyz)))
+	})
+	server := httptest.NewTLSServer(mux)
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, caBundle
+}
+
+func newCluster(caBundle []byte, addresses ...string) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: testNamespace,
+		},
+	}
+	cluster.Spec.KubernetesAPIEndpoints.CABundle = caBundle
+	for _, address := range addresses {
+		cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = append(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints,
+			v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: address})
+	}
+	return cluster
+}
+
+// syncCluster runs a single sync of the controller against cluster at time
+// now and returns the resulting ClusterOK condition.
+func syncCluster(t *testing.T, cluster *v1alpha1.Cluster, now metav1.Time) *v1alpha1.ClusterCondition {
+	return getCondition(t, syncClusterStatus(t, cluster, now), v1alpha1.ClusterOK)
+}
+
+// syncClusterStatus runs a single sync of the controller against cluster at
+// time now and returns the resulting status.
+func syncClusterStatus(t *testing.T, cluster *v1alpha1.Cluster, now metav1.Time) *v1alpha1.ClusterStatus {
+	client := fake.NewSimpleClientset(cluster)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	c := NewController(client, informerFactory, DefaultProbeInterval, DefaultProbeTimeout, 0)
+	c.now = func() metav1.Time { return now }
+
+	if err := informerFactory.Clusterregistry().V1alpha1().Clusters().Informer().GetIndexer().Add(cluster); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := c.syncHandler(testNamespace + "/" + cluster.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.RequeueAfter != DefaultProbeInterval {
+		t.Errorf("Expected the cluster to be probed again after %v, got %+v", DefaultProbeInterval, result)
+	}
+
+	updated, err := client.ClusterregistryV1alpha1().Clusters(testNamespace).Get(context.TODO(), cluster.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return &updated.Status
+}
+
+func getCondition(t *testing.T, status *v1alpha1.ClusterStatus, conditionType v1alpha1.ClusterConditionType) *v1alpha1.ClusterCondition {
+	condition := helper.GetClusterCondition(status, conditionType)
+	if condition == nil {
+		t.Fatalf("Expected a %q condition, got %v", conditionType, status.Conditions)
+	}
+	return condition
+}
+
+func TestSyncClusterOK(t *testing.T) {
+	healthy, healthyCA := newAPIServer(http.StatusOK, http.StatusOK)
+	defer healthy.Close()
+	legacy, legacyCA := newAPIServer(http.StatusOK, http.StatusNotFound)
+	defer legacy.Close()
+	notReady, notReadyCA := newAPIServer(http.StatusOK, http.StatusInternalServerError)
+	defer notReady.Close()
+	unhealthy, unhealthyCA := newAPIServer(http.StatusInternalServerError, http.StatusOK)
+	defer unhealthy.Close()
+	closed, closedCA := newAPIServer(http.StatusOK, http.StatusOK)
+	closed.Close()
+
+	address := func(s *httptest.Server) string { return s.Listener.Addr().String() }
+
+	tests := []struct {
+		name    string
+		cluster *v1alpha1.Cluster
+		status  corev1.ConditionStatus
+		reason  string
+	}{
+		{"healthy", newCluster(healthyCA, address(healthy)), corev1.ConditionTrue, ReasonHealthy},
+		{"healthy with scheme", newCluster(healthyCA, healthy.URL), corev1.ConditionTrue, ReasonHealthy},
+		{"no readyz", newCluster(legacyCA, address(legacy)), corev1.ConditionTrue, ReasonHealthy},
+		{"not ready", newCluster(notReadyCA, address(notReady)), corev1.ConditionFalse, ReasonUnhealthy},
+		{"unhealthy", newCluster(unhealthyCA, address(unhealthy)), corev1.ConditionFalse, ReasonUnhealthy},
+		{"unreachable", newCluster(closedCA, address(closed)), corev1.ConditionFalse, ReasonUnreachable},
+		{"untrusted", newCluster(newTestCA(), address(healthy)), corev1.ConditionFalse, ReasonUnreachable},
+		{"second endpoint healthy", newCluster(healthyCA, address(closed), address(healthy)), corev1.ConditionTrue, ReasonHealthy},
+		{"no endpoints", newCluster(healthyCA), corev1.ConditionUnknown, ReasonNoServerEndpoints},
+		{"invalid CA bundle", newCluster([]byte("garbage"), address(healthy)), corev1.ConditionUnknown, ReasonInvalidCABundle},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			condition := syncCluster(t, tc.cluster, metav1.Now())
+			if condition.Status != tc.status {
+				t.Errorf("Expected status %q, got %q (%s)", tc.status, condition.Status, condition.Message)
+			}
+			if condition.Reason != tc.reason {
+				t.Errorf("Expected reason %q, got %q (%s)", tc.reason, condition.Reason, condition.Message)
+			}
+		})
+	}
+}
+
+func TestSyncClusterReady(t *testing.T) {
+	healthy, healthyCA := newAPIServer(http.StatusOK, http.StatusOK)
+	defer healthy.Close()
+	notReady, notReadyCA := newAPIServer(http.StatusOK, http.StatusInternalServerError)
+	defer notReady.Close()
+	unauthorized, unauthorizedCA := newAPIServer(http.StatusUnauthorized, http.StatusUnauthorized)
+	defer unauthorized.Close()
+	closed, closedCA := newAPIServer(http.StatusOK, http.StatusOK)
+	closed.Close()
+
+	address := func(s *httptest.Server) string { return s.Listener.Addr().String() }
+	const (
+		yes     = corev1.ConditionTrue
+		no      = corev1.ConditionFalse
+		unknown = corev1.ConditionUnknown
+	)
+
+	tests := []struct {
+		name                                              string
+		cluster                                           *v1alpha1.Cluster
+		reachable, certificateValid, authenticated, ready corev1.ConditionStatus
+	}{
+		{"healthy", newCluster(healthyCA, address(healthy)), yes, yes, yes, yes},
+		{"not ready", newCluster(notReadyCA, address(notReady)), yes, yes, yes, yes},
+		{"unauthorized", newCluster(unauthorizedCA, address(unauthorized)), yes, yes, no, no},
+		{"untrusted", newCluster(newTestCA(), address(healthy)), yes, no, unknown, no},
+		{"wrong host", newCluster(healthyCA, strings.Replace(address(healthy), "127.0.0.1", "localhost", 1)), yes, no, unknown, no},
+		{"unreachable", newCluster(closedCA, address(closed)), no, unknown, unknown, no},
+		{"second endpoint untrusted", newCluster(newTestCA(), address(closed), address(healthy)), yes, no, unknown, no},
+		{"no endpoints", newCluster(healthyCA), unknown, unknown, unknown, unknown},
+		{"invalid CA bundle", newCluster([]byte("garbage"), address(healthy)), unknown, no, unknown, no},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			status := syncClusterStatus(t, tc.cluster, metav1.Now())
+			for conditionType, want := range map[v1alpha1.ClusterConditionType]corev1.ConditionStatus{
+				v1alpha1.ClusterReachable:        tc.reachable,
+				v1alpha1.ClusterCertificateValid: tc.certificateValid,
+				v1alpha1.ClusterAuthenticated:    tc.authenticated,
+				v1alpha1.ClusterReady:            tc.ready,
+			} {
+				condition := getCondition(t, status, conditionType)
+				if condition.Status != want {
+					t.Errorf("Expected %s to be %q, got %q (%s: %s)", conditionType, want, condition.Status, condition.Reason, condition.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncClusterOKTimes(t *testing.T) {
+	server, caBundle := newAPIServer(http.StatusOK, http.StatusOK)
+	defer server.Close()
+
+	start := metav1.NewTime(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(start.Add(time.Minute))
+
+	tests := []struct {
+		name           string
+		previous       corev1.ConditionStatus
+		wantTransition metav1.Time
+	}{
+		{"unchanged", corev1.ConditionTrue, start},
+		{"changed", corev1.ConditionFalse, later},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newCluster(caBundle, server.Listener.Addr().String())
+			cluster.Status.Conditions = []v1alpha1.ClusterCondition{{
+				Type:               v1alpha1.ClusterOK,
+				Status:             tc.previous,
+				LastHeartbeatTime:  start,
+				LastTransitionTime: start,
+			}}
+
+			condition := syncCluster(t, cluster, later)
+			if !condition.LastHeartbeatTime.Equal(&later) {
+				t.Errorf("Expected LastHeartbeatTime %v, got %v", later, condition.LastHeartbeatTime)
+			}
+			if !condition.LastTransitionTime.Equal(&tc.wantTransition) {
+				t.Errorf("Expected LastTransitionTime %v, got %v", tc.wantTransition, condition.LastTransitionTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterstatus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxProbeBodyBytes bounds how much of a probe response body is read and
+// reported in the condition message.
+const maxProbeBodyBytes = 256
+
+// httpStatusError is returned when an API server responds to a probe with an
+// unexpected HTTP status code. It indicates that the server is reachable but
+// not healthy.
+type httpStatusError struct {
+	url        string
+	statusCode int
+	body       string
+}
+
+func (e *httpStatusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("GET %s returned %d", e.url, e.statusCode)
+	}
+	return fmt.Sprintf("GET %s returned %d: %s", e.url, e.statusCode, e.body)
+}
+
+// probeStage describes how far the probe of an API server endpoint got before
+// it failed. Later stages imply that the earlier ones succeeded.
+type probeStage int
+
+const (
+	// stageUnreachable means that no connection could be established.
+	stageUnreachable probeStage = iota
+	// stageConnected means that a connection was established, but the
+	// serving certificate of the API server could not be verified.
+	stageConnected
+	// stageVerified means that the serving certificate of the API server
+	// was verified, but the API server rejected the probe as unauthorized.
+	stageVerified
+	// stageAccepted means that the API server accepted the probe, whatever
+	// its outcome.
+	stageAccepted
+)
+
+// probeStageOf returns the stage reached by a probe that returned err.
+func probeStageOf(err error) probeStage {
+	if err == nil {
+		return stageAccepted
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.statusCode == http.StatusUnauthorized {
+			return stageVerified
+		}
+		return stageAccepted
+	}
+	if isCertificateError(err) {
+		return stageConnected
+	}
+	return stageUnreachable
+}
+
+// isCertificateError returns true if err was caused by the verification of a
+// serving certificate.
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid)
+}
+
+// newProbeClient returns an HTTP client that trusts the certificates in
+// caBundle, or the system roots if caBundle is empty.
+func newProbeClient(caBundle []byte, timeout time.Duration) (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("CABundle contains no valid PEM-encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+// probeEndpoint checks the /healthz and /readyz endpoints of the API server at
+// base. API servers that predate /readyz respond to it with 404, which is
+// not treated as a failure.
+func probeEndpoint(client *http.Client, base *url.URL) error {
+	if err := probePath(client, base, "/healthz", false); err != nil {
+		return err
+	}
+	return probePath(client, base, "/readyz", true)
+}
+
+func probePath(client *http.Client, base *url.URL, path string, allowNotFound bool) error {
+	u := *base
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxProbeBodyBytes))
+
+	if resp.StatusCode == http.StatusOK || (allowNotFound && resp.StatusCode == http.StatusNotFound) {
+		return nil
+	}
+	return &httpStatusError{
+		url:        u.String(),
+		statusCode: resp.StatusCode,
+		body:       strings.TrimSpace(string(body)),
+	}
+}
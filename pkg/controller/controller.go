@@ -0,0 +1,306 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions/clusterregistry/v1alpha1"
+)
+
+// DefaultMaxReconcileDuration is the default duration after which a worker
+// that is still reconciling the same key is reported as stuck by CheckHealth.
+const DefaultMaxReconcileDuration = 5 * time.Minute
+
+// ReconcileFunc reconciles the object identified by key, which is of the
+// form namespace/name. The object may have been deleted since key was
+// queued, so a ReconcileFunc must get the current state of the object, e.g.
+// from a lister, rather than rely on the event that queued key.
+//
+// If it returns an error, key is requeued with rate limiting.
+type ReconcileFunc func(key string) (Result, error)
+
+// Result is the outcome of a successful reconciliation.
+type Result struct {
+	// Requeue requeues the key with rate limiting, as if reconciling it had
+	// failed, but without reporting an error.
+	Requeue bool
+
+	// RequeueAfter, if positive, requeues the key after this duration, e.g.
+	// to refresh the state of the object periodically. It is ignored if
+	// Requeue is true.
+	RequeueAfter time.Duration
+}
+
+// UpdatePredicate returns true if the update of a Cluster from oldCluster to
+// newCluster needs to be reconciled.
+type UpdatePredicate func(oldCluster, newCluster *v1alpha1.Cluster) bool
+
+// SpecChanged is an UpdatePredicate that ignores the updates of a Cluster
+// that leave its spec unchanged, e.g. status updates and resyncs.
+func SpecChanged(oldCluster, newCluster *v1alpha1.Cluster) bool {
+	return !apiequality.Semantic.DeepEqual(oldCluster.Spec, newCluster.Spec)
+}
+
+// Options are the optional settings of a Controller.
+type Options struct {
+	// RateLimiter limits how often a key is requeued after a failure.
+	// Defaults to workqueue.DefaultControllerRateLimiter().
+	RateLimiter workqueue.RateLimiter
+
+	// MaxReconcileDuration is the duration after which a worker that is
+	// still reconciling the same key is reported as stuck by CheckHealth.
+	// The reconciliation is not interrupted. Defaults to
+	// DefaultMaxReconcileDuration.
+	MaxReconcileDuration time.Duration
+}
+
+// Controller queues the keys of objects, usually Clusters, and reconciles
+// them with a ReconcileFunc in a pool of workers. A key is never reconciled
+// by two workers at the same time: if it is queued again while it is being
+// reconciled, it is reconciled once more afterwards. Panics in the
+// ReconcileFunc are recovered and handled like errors.
+type Controller struct {
+	// name identifies the controller in logs, and names its workqueue.
+	name string
+
+	reconcile ReconcileFunc
+
+	// workqueue is a rate limited work queue holding the keys to reconcile.
+	workqueue workqueue.RateLimitingInterface
+
+	// synced are the functions reporting whether the caches the controller
+	// reads from have synced.
+	synced []cache.InformerSynced
+
+	maxReconcileDuration time.Duration
+
+	// reconciling holds the time at which the workers started reconciling
+	// the keys they are currently reconciling.
+	reconcilingLock sync.Mutex
+	reconciling     map[string]time.Time
+}
+
+// New returns a controller named name that reconciles keys with reconcile.
+// Keys are added with Enqueue or by the event handlers registered by
+// WatchClusters.
+func New(name string, reconcile ReconcileFunc, options Options) *Controller {
+	rateLimiter := options.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = workqueue.DefaultControllerRateLimiter()
+	}
+	maxReconcileDuration := options.MaxReconcileDuration
+	if maxReconcileDuration <= 0 {
+		maxReconcileDuration = DefaultMaxReconcileDuration
+	}
+	return &Controller{
+		name:                 name,
+		reconcile:            reconcile,
+		workqueue:            workqueue.NewNamedRateLimitingQueue(rateLimiter, name),
+		maxReconcileDuration: maxReconcileDuration,
+		reconciling:          map[string]time.Time{},
+	}
+}
+
+// Name returns the name of the controller.
+func (c *Controller) Name() string {
+	return c.name
+}
+
+// WatchClusters registers event handlers with informer that enqueue the
+// Clusters that are added, updated or deleted, and waits for informer to sync
+// before starting the workers in Run. Updates are only enqueued if all
+// predicates return true.
+func (c *Controller) WatchClusters(informer informers.ClusterInformer, predicates ...UpdatePredicate) {
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: c.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCluster, newCluster := oldObj.(*v1alpha1.Cluster), newObj.(*v1alpha1.Cluster)
+			for _, predicate := range predicates {
+				if !predicate(oldCluster, newCluster) {
+					return
+				}
+			}
+			c.Enqueue(newObj)
+		},
+		DeleteFunc: c.Enqueue,
+	})
+	c.WaitForCacheSync(informer.Informer().HasSynced)
+}
+
+// WaitForCacheSync makes Run wait for synced to return true before starting
+// the workers. Use it for the informers a controller reads from without
+// watching them.
+func (c *Controller) WaitForCacheSync(synced ...cache.InformerSynced) {
+	c.synced = append(c.synced, synced...)
+}
+
+// Enqueue adds the key of obj to the queue. obj may be a
+// cache.DeletedFinalStateUnknown.
+func (c *Controller) Enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.Add(key)
+}
+
+// EnqueueAfter adds the key of obj to the queue after duration.
+func (c *Controller) EnqueueAfter(obj interface{}, duration time.Duration) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, duration)
+}
+
+// Run waits for the caches to sync and starts workers workers. It will block
+// until stopCh is closed, at which point it will shutdown the workqueue and
+// wait for the workers to finish reconciling their current keys.
+func (c *Controller) Run(workers int, stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	klog.Infof("Starting %s controller", c.name)
+
+	klog.Infof("Waiting for informer caches of %s controller to sync", c.name)
+	if ok := cache.WaitForCacheSync(stopCh, c.synced...); !ok {
+		return errors.Errorf("failed to wait for caches of %s controller to sync", c.name)
+	}
+
+	klog.Infof("Starting %d workers of %s controller", workers, c.name)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait.Until(func() { c.runWorker(stopCh) }, time.Second, stopCh)
+		}()
+	}
+
+	<-stopCh
+	klog.Infof("Shutting down workers of %s controller", c.name)
+	c.workqueue.ShutDown()
+	wg.Wait()
+	klog.Infof("Stopped %s controller", c.name)
+
+	return nil
+}
+
+// CheckHealth returns an error if a worker has been reconciling the same key
+// for longer than Options.MaxReconcileDuration, e.g. because it is deadlocked
+// or waits for a request without a timeout.
+func (c *Controller) CheckHealth() error {
+	c.reconcilingLock.Lock()
+	defer c.reconcilingLock.Unlock()
+	var stuck []string
+	for key, start := range c.reconciling {
+		if time.Since(start) > c.maxReconcileDuration {
+			stuck = append(stuck, key)
+		}
+	}
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		return errors.Errorf("workers of %s controller have been reconciling %s for more than %v", c.name, strings.Join(stuck, ", "), c.maxReconcileDuration)
+	}
+	return nil
+}
+
+// runWorker reconciles the keys of the workqueue until it is shut down or
+// stopCh is closed.
+func (c *Controller) runWorker(stopCh <-chan struct{}) {
+	for c.processNextWorkItem(stopCh) {
+	}
+}
+
+// processNextWorkItem will read a single key off the workqueue and reconcile
+// it. It returns false when the worker must stop.
+func (c *Controller) processNextWorkItem(stopCh <-chan struct{}) bool {
+	obj, shutdown := c.workqueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.workqueue.Done(obj)
+
+	select {
+	case <-stopCh:
+		// Leave the keys that are still queued to the next instance of
+		// the controller rather than delay the shutdown.
+		return false
+	default:
+	}
+
+	key, ok := obj.(string)
+	if !ok {
+		c.workqueue.Forget(obj)
+		runtime.HandleError(errors.Errorf("expected string in workqueue but got %#v", obj))
+		return true
+	}
+
+	result, err := c.reconcileHandler(key)
+	switch {
+	case err != nil:
+		c.workqueue.AddRateLimited(key)
+		runtime.HandleError(errors.Wrapf(err, "error syncing '%s'", key))
+	case result.Requeue:
+		c.workqueue.AddRateLimited(key)
+	case result.RequeueAfter > 0:
+		c.workqueue.Forget(obj)
+		c.workqueue.AddAfter(key, result.RequeueAfter)
+	default:
+		c.workqueue.Forget(obj)
+		klog.V(4).Infof("Successfully synced '%s'", key)
+	}
+	return true
+}
+
+// reconcileHandler calls reconcile for key, turns a panic in reconcile into an
+// error and records the reconcile metrics. It tracks the keys that are being
+// reconciled for CheckHealth.
+func (c *Controller) reconcileHandler(key string) (result Result, err error) {
+	start := time.Now()
+	c.reconcilingLock.Lock()
+	c.reconciling[key] = start
+	c.reconcilingLock.Unlock()
+	defer func() {
+		c.reconcilingLock.Lock()
+		delete(c.reconciling, key)
+		c.reconcilingLock.Unlock()
+		if r := recover(); r != nil {
+			klog.Errorf("Observed a panic in %s controller while syncing '%s': %v\n%s", c.name, key, r, debug.Stack())
+			err = errors.Errorf("panic: %v", r)
+		}
+		observeReconcile(c.name, time.Since(start), result, err)
+	}()
+	return c.reconcile(key)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+)
+
+const waitTimeout = 10 * time.Second
+
+func newCluster(name string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+}
+
+// fastOptions returns Options that requeue failed keys without delay.
+func fastOptions() Options {
+	return Options{RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(0, 0)}
+}
+
+func TestProcessNextWorkItem(t *testing.T) {
+	tests := []struct {
+		name         string
+		result       Result
+		err          error
+		panic        bool
+		wantRequeues int
+		wantQueued   bool
+	}{
+		{name: "success"},
+		{name: "error", err: errors.New("failed"), wantRequeues: 1, wantQueued: true},
+		{name: "panic", panic: true, wantRequeues: 1, wantQueued: true},
+		{name: "requeue", result: Result{Requeue: true}, wantRequeues: 1, wantQueued: true},
+		{name: "requeue after", result: Result{RequeueAfter: time.Millisecond}, wantQueued: true},
+		{name: "requeue after in an hour", result: Result{RequeueAfter: time.Hour}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var keys []string
+			c := New("test", func(key string) (Result, error) {
+				keys = append(keys, key)
+				if tc.panic {
+					panic("reconcile panicked")
+				}
+				return tc.result, tc.err
+			}, fastOptions())
+			defer c.workqueue.ShutDown()
+
+			c.Enqueue(newCluster("cluster"))
+			if !c.processNextWorkItem(make(chan struct{})) {
+				t.Fatalf("Expected the worker to continue")
+			}
+			if len(keys) != 1 || keys[0] != "default/cluster" {
+				t.Errorf("Expected default/cluster to be reconciled once, got %v", keys)
+			}
+			if requeues := c.workqueue.NumRequeues("default/cluster"); requeues != tc.wantRequeues {
+				t.Errorf("Expected %d requeues, got %d", tc.wantRequeues, requeues)
+			}
+
+			// Delayed and rate limited keys are added asynchronously.
+			queued := waitFor(func() bool { return c.workqueue.Len() == 1 }, 200*time.Millisecond)
+			if queued != tc.wantQueued {
+				t.Errorf("Expected queued %t, got %t", tc.wantQueued, queued)
+			}
+		})
+	}
+}
+
+func TestEnqueueDeletedFinalStateUnknown(t *testing.T) {
+	c := New("test", func(key string) (Result, error) { return Result{}, nil }, Options{})
+	defer c.workqueue.ShutDown()
+
+	c.Enqueue(cache.DeletedFinalStateUnknown{Key: "default/deleted", Obj: newCluster("deleted")})
+	key, _ := c.workqueue.Get()
+	if key != "default/deleted" {
+		t.Errorf("Expected default/deleted, got %v", key)
+	}
+}
+
+func TestRunWatchClusters(t *testing.T) {
+	client := fake.NewSimpleClientset(newCluster("existing"))
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+
+	var mu sync.Mutex
+	reconciled := map[string]int{}
+	c := New("test", func(key string) (Result, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reconciled[key]++
+		return Result{}, nil
+	}, Options{})
+	c.WatchClusters(informerFactory.Clusterregistry().V1alpha1().Clusters(), SpecChanged)
+	count := func(key string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return reconciled[key]
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(2, stopCh) }()
+	informerFactory.Start(stopCh)
+
+	if !waitFor(func() bool { return count("default/existing") == 1 }, waitTimeout) {
+		t.Fatalf("Expected the existing cluster to be reconciled")
+	}
+
+	clusters := client.ClusterregistryV1alpha1().Clusters("default")
+	cluster, err := clusters.Create(context.TODO(), newCluster("new"), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !waitFor(func() bool { return count("default/new") == 1 }, waitTimeout) {
+		t.Fatalf("Expected the new cluster to be reconciled")
+	}
+
+	// A status update is filtered out by SpecChanged, so the deletion that
+	// follows is the next reconciliation of the cluster.
+	cluster.Status.Conditions = []v1alpha1.ClusterCondition{{Type: v1alpha1.ClusterOK}}
+	if _, err := clusters.UpdateStatus(context.TODO(), cluster, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := clusters.Delete(context.TODO(), "new", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !waitFor(func() bool { return count("default/new") == 2 }, waitTimeout) {
+		t.Fatalf("Expected the deleted cluster to be reconciled")
+	}
+	if n := count("default/new"); n != 2 {
+		t.Errorf("Expected 2 reconciliations of the new cluster, got %d", n)
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRunSerializesKeys(t *testing.T) {
+	var mu sync.Mutex
+	running := map[string]bool{}
+	var reconciled int
+	var overlapped bool
+	c := New("test", func(key string) (Result, error) {
+		mu.Lock()
+		if running[key] {
+			overlapped = true
+		}
+		running[key] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running[key] = false
+		reconciled++
+		mu.Unlock()
+		return Result{}, nil
+	}, Options{})
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(4, stopCh) }()
+
+	for i := 0; i < 100; i++ {
+		c.Enqueue(newCluster("cluster"))
+		c.Enqueue(newCluster("other"))
+		time.Sleep(100 * time.Microsecond)
+	}
+	if !waitFor(func() bool { return c.workqueue.Len() == 0 }, waitTimeout) {
+		t.Fatalf("Expected the queue to be drained")
+	}
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if overlapped {
+		t.Errorf("Expected a key to never be reconciled concurrently")
+	}
+	if reconciled == 0 {
+		t.Errorf("Expected keys to be reconciled")
+	}
+}
+
+func TestRunWaitsForWorkers(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished bool
+	c := New("test", func(key string) (Result, error) {
+		close(started)
+		<-release
+		finished = true
+		return Result{}, nil
+	}, Options{})
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(1, stopCh) }()
+
+	c.Enqueue(newCluster("cluster"))
+	c.Enqueue(newCluster("queued"))
+	<-started
+	close(stopCh)
+
+	select {
+	case <-done:
+		t.Fatalf("Expected Run to wait for the reconciliation in progress")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !finished {
+		t.Errorf("Expected the reconciliation in progress to finish")
+	}
+}
+
+func TestRunCacheSyncFailure(t *testing.T) {
+	c := New("test", func(key string) (Result, error) { return Result{}, nil }, Options{})
+	c.WaitForCacheSync(func() bool { return false })
+
+	stopCh := make(chan struct{})
+	close(stopCh)
+	if err := c.Run(1, stopCh); err == nil {
+		t.Errorf("Expected an error when the caches do not sync")
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	release := make(chan struct{})
+	c := New("test", func(key string) (Result, error) {
+		<-release
+		return Result{}, nil
+	}, Options{MaxReconcileDuration: 50 * time.Millisecond})
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- c.Run(1, stopCh) }()
+
+	if err := c.CheckHealth(); err != nil {
+		t.Errorf("Unexpected error before reconciling: %v", err)
+	}
+	c.Enqueue(newCluster("cluster"))
+	if !waitFor(func() bool { return c.CheckHealth() != nil }, waitTimeout) {
+		t.Errorf("Expected an error while a worker is stuck")
+	}
+
+	close(release)
+	if !waitFor(func() bool { return c.CheckHealth() == nil }, waitTimeout) {
+		t.Errorf("Unexpected error once the worker is done: %v", c.CheckHealth())
+	}
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// waitFor polls condition until it returns true or timeout expires, and returns
+// the last result of condition.
+func waitFor(condition func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
@@ -14,5 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package controller is unused by the cluster registry but required by kubebuilder.
+// Package controller contains controllers that report on and act upon the
+// clusters in the cluster registry. Each controller lives in its own
+// subpackage, and is built on the Controller of this package, which runs a
+// ReconcileFunc for the keys of the clusters that change.
 package controller
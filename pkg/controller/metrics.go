@@ -0,0 +1,78 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/cluster-registry/pkg/metrics"
+)
+
+// Results of a reconciliation, in the result label of reconcileTotal.
+const (
+	resultSuccess      = "success"
+	resultError        = "error"
+	resultRequeue      = "requeue"
+	resultRequeueAfter = "requeue_after"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_total",
+		Help:      "Total number of reconciliations by controller and result.",
+	}, []string{"controller", "result"})
+
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of reconciliations that failed or panicked, by controller.",
+	}, []string{"controller"})
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliations, by controller.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"controller"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileTotal, reconcileErrors, reconcileDuration)
+}
+
+// observeReconcile records a reconciliation by the controller named name
+// that took duration and returned result and err.
+func observeReconcile(name string, duration time.Duration, result Result, err error) {
+	reconcileDuration.WithLabelValues(name).Observe(duration.Seconds())
+	switch {
+	case err != nil:
+		reconcileErrors.WithLabelValues(name).Inc()
+		reconcileTotal.WithLabelValues(name, resultError).Inc()
+	case result.Requeue:
+		reconcileTotal.WithLabelValues(name, resultRequeue).Inc()
+	case result.RequeueAfter > 0:
+		reconcileTotal.WithLabelValues(name, resultRequeueAfter).Inc()
+	default:
+		reconcileTotal.WithLabelValues(name, resultSuccess).Inc()
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// value returns the value of a counter, or the sample count of a histogram.
+func value(t *testing.T, metric prometheus.Metric) float64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := metric.Write(m); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Histogram != nil {
+		return float64(m.Histogram.GetSampleCount())
+	}
+	return m.Counter.GetValue()
+}
+
+func TestReconcileMetrics(t *testing.T) {
+	const name = "metrics-test"
+	results := []struct {
+		result Result
+		err    error
+		panic  bool
+	}{
+		{},
+		{err: errors.New("failed")},
+		{panic: true},
+		{result: Result{Requeue: true}},
+		{result: Result{RequeueAfter: 1}},
+	}
+
+	i := 0
+	c := New(name, func(key string) (Result, error) {
+		r := results[i]
+		i++
+		if r.panic {
+			panic("reconcile panicked")
+		}
+		return r.result, r.err
+	}, fastOptions())
+	defer c.workqueue.ShutDown()
+	defer func() {
+		reconcileTotal.DeletePartialMatch(prometheus.Labels{"controller": name})
+		reconcileErrors.DeleteLabelValues(name)
+		reconcileDuration.DeleteLabelValues(name)
+	}()
+
+	for range results {
+		c.reconcileHandler("default/cluster")
+	}
+
+	for result, want := range map[string]float64{
+		resultSuccess:      1,
+		resultError:        2,
+		resultRequeue:      1,
+		resultRequeueAfter: 1,
+	} {
+		if got := value(t, reconcileTotal.WithLabelValues(name, result)); got != want {
+			t.Errorf("Expected %v reconciliations with result %s, got %v", want, result, got)
+		}
+	}
+	if got := value(t, reconcileErrors.WithLabelValues(name)); got != 2 {
+		t.Errorf("Expected 2 errors, got %v", got)
+	}
+	if got := value(t, reconcileDuration.WithLabelValues(name).(prometheus.Histogram)); got != 5 {
+		t.Errorf("Expected 5 observed durations, got %v", got)
+	}
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crctl
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/validation"
+)
+
+// registerOptions holds the flags of the register command.
+type registerOptions struct {
+	serverAddresses  []string
+	caFile           string
+	controllerSecret string
+	labels           []string
+}
+
+func newRegisterCommand(o *Options) *cobra.Command {
+	r := &registerOptions{}
+	cmd := &cobra.Command{
+		Use:   "register NAME --server-address [CIDR=]ADDRESS...",
+		Short: "Register a cluster",
+		Example: `  # Register a cluster reachable at 10.0.0.1 from 10.0.0.0/8 and at cluster.example.com from elsewhere.
+  crctl register my-cluster --server-address 10.0.0.0/8=10.0.0.1 --server-address cluster.example.com --ca-file ca.crt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return r.run(o, args[0])
+		},
+	}
+	cmd.Flags().StringSliceVar(&r.serverAddresses, "server-address", nil, "A server endpoint of the cluster, as ADDRESS or CIDR=ADDRESS. ADDRESS alone is reachable from 0.0.0.0/0. May be repeated.")
+	cmd.Flags().StringVar(&r.caFile, "ca-file", "", "Path to a PEM-encoded certificate authority bundle of the cluster.")
+	cmd.Flags().StringVar(&r.controllerSecret, "controller-secret", "", "The [NAMESPACE/]NAME of a Secret holding credentials for controllers.")
+	cmd.Flags().StringSliceVarP(&r.labels, "labels", "l", nil, "Labels of the cluster, as KEY=VALUE. May be repeated.")
+	return cmd
+}
+
+func (r *registerOptions) run(o *Options, name string) error {
+	namespace, err := o.namespace()
+	if err != nil {
+		return err
+	}
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+
+	if len(r.serverAddresses) == 0 {
+		return errors.New("at least one --server-address is required")
+	}
+	for _, serverAddress := range r.serverAddresses {
+		endpoint := v1alpha1.ServerAddressByClientCIDR{ClientCIDR: "0.0.0.0/0", ServerAddress: serverAddress}
+		if i := strings.Index(serverAddress, "="); i >= 0 {
+			endpoint.ClientCIDR, endpoint.ServerAddress = serverAddress[:i], serverAddress[i+1:]
+		}
+		cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = append(cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints, endpoint)
+	}
+
+	if r.caFile != "" {
+		if cluster.Spec.KubernetesAPIEndpoints.CABundle, err = ioutil.ReadFile(r.caFile); err != nil {
+			return errors.Wrap(err, "failed to read CA file")
+		}
+	}
+
+	if r.controllerSecret != "" {
+		ref := &v1alpha1.ObjectReference{Kind: "Secret", Name: r.controllerSecret}
+		if i := strings.Index(r.controllerSecret, "/"); i >= 0 {
+			ref.Namespace, ref.Name = r.controllerSecret[:i], r.controllerSecret[i+1:]
+		}
+		cluster.Spec.AuthInfo.Controller = ref
+	}
+
+	if len(r.labels) > 0 {
+		labels, remove, err := parseLabels(r.labels)
+		if err != nil {
+			return err
+		}
+		if len(remove) > 0 {
+			return errors.Errorf("invalid labels %v: expected KEY=VALUE", remove)
+		}
+		cluster.Labels = labels
+	}
+
+	if err := validation.ValidateCluster(cluster).ToAggregate(); err != nil {
+		return errors.Wrap(err, "invalid cluster")
+	}
+
+	client, err := o.client()
+	if err != nil {
+		return err
+	}
+	created, err := client.ClusterregistryV1alpha1().Clusters(namespace).Create(context.TODO(), cluster, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	return o.printResult(created, "registered")
+}
+
+func newUnregisterCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unregister NAME...",
+		Short: "Unregister one or more clusters",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			client, err := o.client()
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if err := client.ClusterregistryV1alpha1().Clusters(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+					return err
+				}
+				fmt.Fprintf(o.Out, "cluster %s/%s unregistered\n", namespace, name)
+			}
+			return nil
+		},
+	}
+}
+
+func newListCommand(o *Options) *cobra.Command {
+	var selector string
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List clusters",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := metav1.NamespaceAll
+			if !o.AllNamespaces {
+				var err error
+				if namespace, err = o.namespace(); err != nil {
+					return err
+				}
+			}
+			client, err := o.client()
+			if err != nil {
+				return err
+			}
+			list, err := client.ClusterregistryV1alpha1().Clusters(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return err
+			}
+
+			list.APIVersion, list.Kind = "v1", "List"
+			for i := range list.Items {
+				withTypeMeta(&list.Items[i])
+			}
+			if printed, err := printObject(o.Out, o.Output, list); printed || err != nil {
+				return err
+			}
+			if len(list.Items) == 0 {
+				fmt.Fprintln(o.ErrOut, "No clusters found.")
+				return nil
+			}
+			return printClusterTable(o.Out, list.Items, o.AllNamespaces)
+		},
+	}
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "List clusters in all namespaces.")
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "A label selector restricting the clusters to list.")
+	return cmd
+}
+
+func newDescribeCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Show the details of a cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cluster, err := o.getCluster(args[0])
+			if err != nil {
+				return err
+			}
+			withTypeMeta(cluster)
+			if printed, err := printObject(o.Out, o.Output, cluster); printed || err != nil {
+				return err
+			}
+			return describeCluster(o.Out, cluster)
+		},
+	}
+}
+
+func newLabelCommand(o *Options) *cobra.Command {
+	var overwrite bool
+	cmd := &cobra.Command{
+		Use:   "label NAME KEY=VALUE... KEY-...",
+		Short: "Add, update or remove labels of a cluster",
+		Example: `  # Add the env=prod label and remove the canary label.
+  crctl label my-cluster env=prod canary-`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			add, remove, err := parseLabels(args[1:])
+			if err != nil {
+				return err
+			}
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			client, err := o.client()
+			if err != nil {
+				return err
+			}
+			clusters := client.ClusterregistryV1alpha1().Clusters(namespace)
+
+			var updated *v1alpha1.Cluster
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				cluster, err := clusters.Get(context.TODO(), args[0], metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				if cluster.Labels == nil {
+					cluster.Labels = map[string]string{}
+				}
+				for k, v := range add {
+					if old, ok := cluster.Labels[k]; ok && old != v && !overwrite {
+						return errors.Errorf("label %q already has value %q; use --overwrite to change it", k, old)
+					}
+					cluster.Labels[k] = v
+				}
+				for _, k := range remove {
+					delete(cluster.Labels, k)
+				}
+				updated, err = clusters.Update(context.TODO(), cluster, metav1.UpdateOptions{})
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return o.printResult(updated, "labeled")
+		},
+	}
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Allow changing the value of existing labels.")
+	return cmd
+}
+
+func newWaitCommand(o *Options) *cobra.Command {
+	var status string
+	var timeout, interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "wait-for-condition NAME CONDITION",
+		Short: "Wait until a condition of a cluster has the given status",
+		Example: `  # Wait up to 5 minutes for my-cluster to be Ready.
+  crctl wait-for-condition my-cluster Ready --timeout 5m`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conditionType := v1alpha1.ClusterConditionType(args[1])
+			wantStatus := corev1.ConditionStatus(status)
+
+			var cluster *v1alpha1.Cluster
+			var lastErr error
+			err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+				var err error
+				if cluster, err = o.getCluster(args[0]); err != nil {
+					// The cluster may not be registered yet, or the API
+					// server may be briefly unavailable.
+					if isRetriableError(err) {
+						lastErr = err
+						return false, nil
+					}
+					return false, err
+				}
+				lastErr = nil
+				condition := helper.GetClusterCondition(&cluster.Status, conditionType)
+				return condition != nil && condition.Status == wantStatus, nil
+			})
+			if err == wait.ErrWaitTimeout {
+				if lastErr != nil {
+					return errors.Wrapf(lastErr, "timed out waiting for condition %s=%s of cluster %s", conditionType, wantStatus, args[0])
+				}
+				return errors.Errorf("timed out waiting for condition %s=%s of cluster %s", conditionType, wantStatus, args[0])
+			} else if err != nil {
+				return err
+			}
+			return o.printResult(cluster, fmt.Sprintf("condition met (%s=%s)", conditionType, wantStatus))
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", string(corev1.ConditionTrue), "The status to wait for. One of True, False, Unknown.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "How long to wait before giving up.")
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "How often to check the condition.")
+	return cmd
+}
+
+// isRetriableError returns true if err, returned by a request to the API
+// server, may not be returned by the same request later: the object is not
+// found, or the API server or the connection to it failed.
+func isRetriableError(err error) bool {
+	return apierrors.IsNotFound(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		utilnet.IsConnectionRefused(err) ||
+		utilnet.IsConnectionReset(err) ||
+		utilnet.IsProbableEOF(err)
+}
+
+// getCluster gets the cluster with the given name in the current namespace.
+func (o *Options) getCluster(name string) (*v1alpha1.Cluster, error) {
+	namespace, err := o.namespace()
+	if err != nil {
+		return nil, err
+	}
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	return client.ClusterregistryV1alpha1().Clusters(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// printResult prints cluster in the structured output format, or a message
+// describing what happened to it otherwise.
+func (o *Options) printResult(cluster *v1alpha1.Cluster, what string) error {
+	withTypeMeta(cluster)
+	if printed, err := printObject(o.Out, o.Output, cluster); printed || err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(o.Out, "cluster %s/%s %s\n", cluster.Namespace, cluster.Name, what)
+	return err
+}
+
+// parseLabels parses KEY=VALUE arguments into labels to add, and KEY-
+// arguments into labels to remove.
+func parseLabels(args []string) (map[string]string, []string, error) {
+	add := map[string]string{}
+	var remove []string
+	for _, arg := range args {
+		switch {
+		case strings.Contains(arg, "="):
+			parts := strings.SplitN(arg, "=", 2)
+			if parts[0] == "" {
+				return nil, nil, errors.Errorf("invalid label %q", arg)
+			}
+			add[parts[0]] = parts[1]
+		case strings.HasSuffix(arg, "-") && len(arg) > 1:
+			remove = append(remove, strings.TrimSuffix(arg, "-"))
+		default:
+			return nil, nil, errors.Errorf("invalid label %q: expected KEY=VALUE or KEY-", arg)
+		}
+	}
+	return add, remove, nil
+}
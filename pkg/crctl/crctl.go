@@ -0,0 +1,120 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crctl implements crctl, a command-line tool for the cluster
+// registry.
+package crctl
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+)
+
+// Options holds the global options of crctl and the dependencies shared by
+// its subcommands.
+type Options struct {
+	// Out and ErrOut are the writers to which commands write their output and
+	// errors.
+	Out    io.Writer
+	ErrOut io.Writer
+
+	Kubeconfig    string
+	MasterURL     string
+	Namespace     string
+	AllNamespaces bool
+	Output        string
+
+	// Client is the clientset used by the commands. If nil, it is built from
+	// Kubeconfig and MasterURL on first use.
+	Client clientset.Interface
+
+	clientConfig clientcmd.ClientConfig
+}
+
+// NewCommand returns the root crctl command.
+func NewCommand(out, errOut io.Writer) *cobra.Command {
+	o := &Options{Out: out, ErrOut: errOut}
+	return NewCommandWithOptions(o)
+}
+
+// NewCommandWithOptions returns the root crctl command using the given
+// options. It allows tests to inject a fake Client.
+func NewCommandWithOptions(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "crctl",
+		Short:         "crctl controls the cluster registry",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.SetOutput(o.ErrOut)
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", "", "Path to a kubeconfig for the cluster registry.")
+	flags.StringVar(&o.MasterURL, "master", "", "The address of the cluster registry's Kubernetes API server. Overrides any value provided in the current context in kubeconfig.")
+	flags.StringVarP(&o.Namespace, "namespace", "n", "", "The namespace of the Clusters. Defaults to the namespace of the current context in kubeconfig.")
+	flags.StringVarP(&o.Output, "output", "o", outputTable, "Output format. One of: table, json, yaml.")
+
+	cmd.AddCommand(
+		newRegisterCommand(o),
+		newUnregisterCommand(o),
+		newListCommand(o),
+		newDescribeCommand(o),
+		newLabelCommand(o),
+		newWaitCommand(o),
+	)
+	return cmd
+}
+
+func (o *Options) loadClientConfig() clientcmd.ClientConfig {
+	if o.clientConfig == nil {
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.ExplicitPath = o.Kubeconfig
+		overrides := &clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: o.MasterURL}}
+		o.clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+	}
+	return o.clientConfig
+}
+
+// client returns the clientset used by the commands.
+func (o *Options) client() (clientset.Interface, error) {
+	if o.Client != nil {
+		return o.Client, nil
+	}
+	config, err := o.loadClientConfig().ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kubeconfig")
+	}
+	if o.Client, err = clientset.NewForConfig(config); err != nil {
+		return nil, errors.Wrap(err, "failed to build cluster clientset")
+	}
+	return o.Client, nil
+}
+
+// namespace returns the namespace that commands operate in.
+func (o *Options) namespace() (string, error) {
+	if o.Namespace != "" {
+		return o.Namespace, nil
+	}
+	namespace, _, err := o.loadClientConfig().Namespace()
+	return namespace, err
+}
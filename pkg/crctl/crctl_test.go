@@ -0,0 +1,198 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+)
+
+// run runs crctl with args against client and returns its output.
+func run(t *testing.T, client *fake.Clientset, args ...string) (string, error) {
+	var out, errOut bytes.Buffer
+	cmd := NewCommandWithOptions(&Options{Out: &out, ErrOut: &errOut, Client: client})
+	cmd.SetArgs(append([]string{"--namespace", "default"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestRegisterListUnregister(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	if _, err := run(t, client, "register", "my-cluster",
+		"--server-address", "10.0.0.0/8=10.0.0.1", "--server-address", "cluster.example.com",
+		"--controller-secret", "secrets/creds", "-l", "env=prod"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cluster, err := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "my-cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []v1alpha1.ServerAddressByClientCIDR{
+		{ClientCIDR: "10.0.0.0/8", ServerAddress: "10.0.0.1"},
+		{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com"},
+	}
+	endpoints := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints
+	if len(endpoints) != 2 || endpoints[0] != want[0] || endpoints[1] != want[1] {
+		t.Errorf("Expected endpoints %v, got %v", want, endpoints)
+	}
+	if ref := cluster.Spec.AuthInfo.Controller; ref == nil || ref.Namespace != "secrets" || ref.Name != "creds" {
+		t.Errorf("Unexpected controller auth info %#v", ref)
+	}
+	if cluster.Labels["env"] != "prod" {
+		t.Errorf("Expected label env=prod, got %v", cluster.Labels)
+	}
+
+	out, err := run(t, client, "list")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "my-cluster") || !strings.Contains(out, "10.0.0.0/8=10.0.0.1,0.0.0.0/0=cluster.example.com") {
+		t.Errorf("Unexpected list output:\n%s", out)
+	}
+
+	out, err = run(t, client, "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var list v1alpha1.ClusterList
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		t.Fatalf("Unexpected error: %v\n%s", err, out)
+	}
+	if len(list.Items) != 1 || list.Items[0].Kind != "Cluster" {
+		t.Errorf("Unexpected list: %#v", list)
+	}
+
+	if _, err := run(t, client, "unregister", "my-cluster"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "my-cluster", metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected the cluster to be deleted")
+	}
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	_, err := run(t, client, "register", "my-cluster", "--server-address", "public=10.0.0.1")
+	if err == nil || !strings.Contains(err.Error(), "spec.kubernetesApiEndpoints.serverEndpoints[0].clientCIDR") {
+		t.Fatalf("Expected a clientCIDR error, got %v", err)
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("Expected no API calls, got %v", client.Actions())
+	}
+}
+
+func TestWaitRetriesErrors(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	gets := 0
+	client.PrependReactor("get", "clusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		gets++
+		switch gets {
+		case 1:
+			return true, nil, apierrors.NewNotFound(v1alpha1.Resource("clusters"), "my-cluster")
+		case 2:
+			return true, nil, apierrors.NewServiceUnavailable("unavailable")
+		}
+		return true, &v1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+			Status: v1alpha1.ClusterStatus{Conditions: []v1alpha1.ClusterCondition{
+				{Type: v1alpha1.ClusterOK, Status: corev1.ConditionTrue},
+			}},
+		}, nil
+	})
+
+	if _, err := run(t, client, "wait-for-condition", "my-cluster", "OK", "--timeout", "1s", "--interval", "10ms"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if gets != 3 {
+		t.Errorf("Expected 3 gets, got %d", gets)
+	}
+}
+
+func TestWaitErrors(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	if _, err := run(t, client, "wait-for-condition", "my-cluster", "OK", "--timeout", "50ms", "--interval", "10ms"); err == nil || !apierrors.IsNotFound(errors.Cause(err)) {
+		t.Errorf("Expected a timeout with the last NotFound error, got %v", err)
+	}
+
+	client.PrependReactor("get", "clusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1alpha1.Resource("clusters"), "my-cluster", errors.New("denied"))
+	})
+	if _, err := run(t, client, "wait-for-condition", "my-cluster", "OK", "--timeout", "10s", "--interval", "10ms"); !apierrors.IsForbidden(err) {
+		t.Errorf("Expected a Forbidden error, got %v", err)
+	}
+	if len(client.Actions()) > 10 {
+		t.Errorf("Expected the wait to stop at the Forbidden error, got %d actions", len(client.Actions()))
+	}
+}
+
+func TestLabel(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{
+		Name: "my-cluster", Namespace: "default", Labels: map[string]string{"env": "test", "canary": "true"},
+	}})
+
+	if _, err := run(t, client, "label", "my-cluster", "env=prod"); err == nil {
+		t.Errorf("Expected an error when changing a label without --overwrite")
+	}
+	if _, err := run(t, client, "label", "my-cluster", "--overwrite", "env=prod", "canary-", "team=a"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cluster, _ := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "my-cluster", metav1.GetOptions{})
+	if len(cluster.Labels) != 2 || cluster.Labels["env"] != "prod" || cluster.Labels["team"] != "a" {
+		t.Errorf("Unexpected labels %v", cluster.Labels)
+	}
+}
+
+func TestDescribeAndWait(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Status: v1alpha1.ClusterStatus{Conditions: []v1alpha1.ClusterCondition{
+			{Type: v1alpha1.ClusterReady, Status: corev1.ConditionTrue, Reason: "SubConditionsTrue"},
+		}},
+	})
+
+	out, err := run(t, client, "describe", "my-cluster")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Ready") || !strings.Contains(out, "SubConditionsTrue") {
+		t.Errorf("Unexpected describe output:\n%s", out)
+	}
+
+	if _, err := run(t, client, "wait-for-condition", "my-cluster", "Ready", "--timeout", "1s", "--interval", "10ms"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := run(t, client, "wait-for-condition", "my-cluster", "Ready", "--status", "False", "--timeout", "50ms", "--interval", "10ms"); err == nil {
+		t.Errorf("Expected a timeout")
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// printObject prints obj in the given structured output format. It returns
+// false if format is not a structured format.
+func printObject(out io.Writer, format string, obj runtime.Object) (bool, error) {
+	var data []byte
+	var err error
+	switch format {
+	case outputJSON:
+		data, err = json.MarshalIndent(obj, "", "    ")
+		data = append(data, '\n')
+	case outputYAML:
+		data, err = yaml.Marshal(obj)
+	case outputTable, "":
+		return false, nil
+	default:
+		return false, errors.Errorf("unknown output format %q", format)
+	}
+	if err != nil {
+		return true, err
+	}
+	_, err = out.Write(data)
+	return true, err
+}
+
+// withTypeMeta sets the apiVersion and kind of clusters, which are not
+// populated by the typed client, so that they appear in structured output.
+func withTypeMeta(clusters ...*v1alpha1.Cluster) {
+	for _, cluster := range clusters {
+		cluster.APIVersion = v1alpha1.SchemeGroupVersion.String()
+		cluster.Kind = "Cluster"
+	}
+}
+
+// printClusterTable prints clusters as a table, including their namespace if
+// withNamespace is set.
+func printClusterTable(out io.Writer, clusters []v1alpha1.Cluster, withNamespace bool) error {
+	w := tabwriter.NewWriter(out, 0, 8, 3, ' ', 0)
+	if withNamespace {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tENDPOINTS\tCONDITIONS\tAGE")
+	for i := range clusters {
+		cluster := &clusters[i]
+		if withNamespace {
+			fmt.Fprintf(w, "%s\t", cluster.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cluster.Name, formatEndpoints(cluster), formatConditions(cluster), age(cluster))
+	}
+	return w.Flush()
+}
+
+// formatEndpoints returns the server endpoints of cluster as a comma-separated
+// list of CIDR=ADDRESS pairs.
+func formatEndpoints(cluster *v1alpha1.Cluster) string {
+	var endpoints []string
+	for _, endpoint := range cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints {
+		endpoints = append(endpoints, endpoint.ClientCIDR+"="+endpoint.ServerAddress)
+	}
+	if len(endpoints) == 0 {
+		return "<none>"
+	}
+	return strings.Join(endpoints, ",")
+}
+
+// formatConditions returns the conditions of cluster as a comma-separated
+// list of TYPE=STATUS pairs, sorted by type.
+func formatConditions(cluster *v1alpha1.Cluster) string {
+	var conditions []string
+	for _, condition := range cluster.Status.Conditions {
+		conditions = append(conditions, fmt.Sprintf("%s=%s", condition.Type, condition.Status))
+	}
+	if len(conditions) == 0 {
+		return "<none>"
+	}
+	sort.Strings(conditions)
+	return strings.Join(conditions, ",")
+}
+
+func age(cluster *v1alpha1.Cluster) string {
+	if cluster.CreationTimestamp.IsZero() {
+		return "<unknown>"
+	}
+	return duration.ShortHumanDuration(time.Since(cluster.CreationTimestamp.Time))
+}
+
+// describeCluster prints a human-readable description of cluster.
+func describeCluster(out io.Writer, cluster *v1alpha1.Cluster) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", cluster.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", cluster.Namespace)
+	fmt.Fprintf(w, "Labels:\t%s\n", formatMap(cluster.Labels))
+	fmt.Fprintf(w, "Annotations:\t%s\n", formatMap(cluster.Annotations))
+	fmt.Fprintf(w, "Created:\t%s (%s ago)\n", cluster.CreationTimestamp.Format(time.RFC3339), age(cluster))
+
+	fmt.Fprintln(w, "Server Endpoints:")
+	fmt.Fprintln(w, "  CLIENT CIDR\tSERVER ADDRESS")
+	for _, endpoint := range cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints {
+		fmt.Fprintf(w, "  %s\t%s\n", endpoint.ClientCIDR, endpoint.ServerAddress)
+	}
+	caBundle := "<none>"
+	if n := len(cluster.Spec.KubernetesAPIEndpoints.CABundle); n > 0 {
+		caBundle = fmt.Sprintf("%d bytes", n)
+	}
+	fmt.Fprintf(w, "CA Bundle:\t%s\n", caBundle)
+	fmt.Fprintf(w, "User Auth Info:\t%s\n", formatReference(cluster.Spec.AuthInfo.User))
+	fmt.Fprintf(w, "Controller Auth Info:\t%s\n", formatReference(cluster.Spec.AuthInfo.Controller))
+
+	status := cluster.Status
+	if status.ServerVersion != "" || status.Platform != "" {
+		fmt.Fprintf(w, "Server Version:\t%s %s\n", status.ServerVersion, status.Platform)
+	}
+	if status.NodeCount != nil {
+		fmt.Fprintf(w, "Nodes:\t%d\n", *status.NodeCount)
+	}
+	for _, resources := range []struct {
+		name string
+		list map[string]string
+	}{
+		{"Capacity", formatResources(status.Capacity)},
+		{"Allocatable", formatResources(status.Allocatable)},
+	} {
+		if len(resources.list) > 0 {
+			fmt.Fprintf(w, "%s:\t%s\n", resources.name, formatMap(resources.list))
+		}
+	}
+
+	fmt.Fprintln(w, "Conditions:")
+	if len(status.Conditions) == 0 {
+		fmt.Fprintln(w, "  <none>")
+	} else {
+		fmt.Fprintln(w, "  TYPE\tSTATUS\tLAST HEARTBEAT\tLAST TRANSITION\tREASON\tMESSAGE")
+		for _, c := range status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\n", c.Type, c.Status,
+				c.LastHeartbeatTime.Format(time.RFC3339), c.LastTransitionTime.Format(time.RFC3339), c.Reason, c.Message)
+		}
+	}
+	return w.Flush()
+}
+
+func formatReference(ref *v1alpha1.ObjectReference) string {
+	if ref == nil {
+		return "<none>"
+	}
+	s := ref.Kind + " " + ref.Name
+	if ref.Namespace != "" {
+		s = ref.Kind + " " + ref.Namespace + "/" + ref.Name
+	}
+	return strings.TrimSpace(s)
+}
+
+func formatResources(resources corev1.ResourceList) map[string]string {
+	m := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		m[string(name)] = quantity.String()
+	}
+	return m
+}
+
+func formatMap(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthz serves the health checks of the controller binaries of the
+// cluster registry, e.g. at /healthz and /readyz, in the format of the
+// Kubernetes components.
+package healthz
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+)
+
+// Checker is a named health check. It has the same methods as the health
+// checks of k8s.io/apiserver and as the leader election health check of
+// k8s.io/client-go/tools/leaderelection.
+type Checker interface {
+	// Name identifies the check in the verbose output and in the exclude
+	// query parameter.
+	Name() string
+
+	// Check returns an error if the check fails.
+	Check(req *http.Request) error
+}
+
+// PingHealthz is a Checker that always succeeds, to check that the server
+// responds at all.
+var PingHealthz Checker = ping{}
+
+type ping struct{}
+
+func (ping) Name() string {
+	return "ping"
+}
+
+func (ping) Check(_ *http.Request) error {
+	return nil
+}
+
+// NamedCheck returns a Checker named name that runs check.
+func NamedCheck(name string, check func(req *http.Request) error) Checker {
+	return &namedCheck{name: name, check: check}
+}
+
+type namedCheck struct {
+	name  string
+	check func(req *http.Request) error
+}
+
+func (c *namedCheck) Name() string {
+	return c.name
+}
+
+func (c *namedCheck) Check(req *http.Request) error {
+	return c.check(req)
+}
+
+// Handler returns an HTTP handler that runs checks. It responds with 200 and
+// "ok" if all of them succeed, or with 500 and the list of the failed checks
+// otherwise. The verbose query parameter lists the results of all the checks,
+// and each exclude query parameter skips the check it names.
+func Handler(checks ...Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		excluded := sets.NewString(req.URL.Query()["exclude"]...)
+		var output bytes.Buffer
+		var failed []string
+		for _, check := range checks {
+			if excluded.Has(check.Name()) {
+				excluded.Delete(check.Name())
+				fmt.Fprintf(&output, "[+]%s excluded: ok\n", check.Name())
+				continue
+			}
+			if err := check.Check(req); err != nil {
+				fmt.Fprintf(&output, "[-]%s failed: %v\n", check.Name(), err)
+				failed = append(failed, check.Name())
+				continue
+			}
+			fmt.Fprintf(&output, "[+]%s ok\n", check.Name())
+		}
+		if excluded.Len() > 0 {
+			fmt.Fprintf(&output, "warn: some health checks cannot be excluded: no matches for %s\n", strings.Join(excluded.List(), ", "))
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if len(failed) > 0 {
+			klog.V(2).Infof("%s check failed: %s", req.URL.Path, strings.Join(failed, ", "))
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(&output, "%s check failed\n", strings.TrimPrefix(req.URL.Path, "/"))
+			output.WriteTo(w)
+			return
+		}
+		if _, verbose := req.URL.Query()["verbose"]; verbose {
+			fmt.Fprintf(&output, "%s check passed\n", strings.TrimPrefix(req.URL.Path, "/"))
+			output.WriteTo(w)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	})
+}
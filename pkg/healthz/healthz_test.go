@@ -0,0 +1,79 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestHandler(t *testing.T) {
+	failing := NamedCheck("failing", func(_ *http.Request) error { return errors.New("broken") })
+	tests := []struct {
+		name       string
+		checks     []Checker
+		query      string
+		wantStatus int
+		wantBody   []string
+	}{
+		{
+			name:       "ok",
+			checks:     []Checker{PingHealthz},
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"ok"},
+		},
+		{
+			name:       "verbose",
+			checks:     []Checker{PingHealthz},
+			query:      "?verbose",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"[+]ping ok\n", "healthz check passed\n"},
+		},
+		{
+			name:       "failed",
+			checks:     []Checker{PingHealthz, failing},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"[+]ping ok\n", "[-]failing failed: broken\n", "healthz check failed\n"},
+		},
+		{
+			name:       "excluded",
+			checks:     []Checker{PingHealthz, failing},
+			query:      "?verbose&exclude=failing&exclude=unknown",
+			wantStatus: http.StatusOK,
+			wantBody:   []string{"[+]failing excluded: ok\n", "no matches for unknown\n"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Handler(tc.checks...).ServeHTTP(recorder, httptest.NewRequest("GET", "/healthz"+tc.query, nil))
+			if recorder.Code != tc.wantStatus {
+				t.Errorf("Expected status %d, got %d", tc.wantStatus, recorder.Code)
+			}
+			for _, want := range tc.wantBody {
+				if !strings.Contains(recorder.Body.String(), want) {
+					t.Errorf("Expected %q in:\n%s", want, recorder.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -14,5 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package inject is unused by the cluster registry but required by kubebuilder.
+// Package inject wires the clients, shared informers and event recorder used
+// by the controllers of the cluster registry, and runs the controllers that
+// are enabled in a single controller manager, which serves their metrics and
+// health over HTTP.
 package inject
@@ -0,0 +1,233 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"context"
+	"flag"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration that non-leader candidates
+	// wait after observing a leadership renewal before trying to acquire the
+	// leadership.
+	DefaultLeaseDuration = 15 * time.Second
+
+	// DefaultRenewDeadline is the default duration that the leader retries
+	// refreshing its leadership before giving it up.
+	DefaultRenewDeadline = 10 * time.Second
+
+	// DefaultRetryPeriod is the default duration between two attempts to
+	// acquire or renew the leadership.
+	DefaultRetryPeriod = 2 * time.Second
+
+	// DefaultLeaseNamespace is the default namespace of the Lease used for
+	// leader election.
+	DefaultLeaseNamespace = "kube-system"
+
+	// DefaultLeaderElectionHealthTimeout is how long after its Lease expired
+	// the leader is reported as unhealthy at /healthz, e.g. when it is
+	// deadlocked and can neither renew the Lease nor give it up.
+	DefaultLeaderElectionHealthTimeout = 20 * time.Second
+)
+
+// LeaderElectionOptions configure the leader election of a Manager. When it
+// is enabled, only the replica of the controller manager that holds a Lease
+// in the Kubernetes API runs the controllers.
+type LeaderElectionOptions struct {
+	// Enabled enables leader election.
+	Enabled bool
+
+	// LeaseNamespace and LeaseName identify the Lease. LeaseNamespace
+	// defaults to DefaultLeaseNamespace, and LeaseName to the component of
+	// the Manager.
+	LeaseNamespace string
+	LeaseName      string
+
+	// Identity identifies this replica in the Lease. Defaults to the
+	// hostname followed by a random suffix.
+	Identity string
+
+	// LeaseDuration, RenewDeadline and RetryPeriod are the durations of the
+	// leader election. They default to DefaultLeaseDuration,
+	// DefaultRenewDeadline and DefaultRetryPeriod.
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// AddFlags adds flags for the leader election options to fs.
+func (o *LeaderElectionOptions) AddFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "leader-elect", false, "Run the controllers only while this replica is the leader, so that several replicas can run for high availability.")
+	fs.StringVar(&o.LeaseNamespace, "leader-elect-resource-namespace", DefaultLeaseNamespace, "The namespace of the Lease used for leader election.")
+	fs.StringVar(&o.LeaseName, "leader-elect-resource-name", "", "The name of the Lease used for leader election. Defaults to the name of the component.")
+	fs.DurationVar(&o.LeaseDuration, "leader-elect-lease-duration", DefaultLeaseDuration, "The duration that non-leader candidates wait after observing a leadership renewal before trying to acquire the leadership.")
+	fs.DurationVar(&o.RenewDeadline, "leader-elect-renew-deadline", DefaultRenewDeadline, "The duration that the leader retries refreshing its leadership before giving it up. Must be less than the lease duration.")
+	fs.DurationVar(&o.RetryPeriod, "leader-elect-retry-period", DefaultRetryPeriod, "The duration between two attempts to acquire or renew the leadership.")
+}
+
+// complete sets the defaults of the unset options.
+func (o *LeaderElectionOptions) complete(component string) error {
+	if o.LeaseNamespace == "" {
+		o.LeaseNamespace = DefaultLeaseNamespace
+	}
+	if o.LeaseName == "" {
+		o.LeaseName = component
+	}
+	if o.Identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "failed to get the hostname")
+		}
+		o.Identity = hostname + "_" + string(uuid.NewUUID())
+	}
+	if o.LeaseDuration == 0 {
+		o.LeaseDuration = DefaultLeaseDuration
+	}
+	if o.RenewDeadline == 0 {
+		o.RenewDeadline = DefaultRenewDeadline
+	}
+	if o.RetryPeriod == 0 {
+		o.RetryPeriod = DefaultRetryPeriod
+	}
+	return nil
+}
+
+// runWithLeaderElection waits until m is the leader, then calls run with a
+// channel that is closed when stopCh is closed or the leadership is lost. The
+// Lease is released once run returns after stopCh was closed, so that another
+// replica can take over without waiting for the Lease to expire. It returns
+// an error if the leadership was lost before stopCh was closed.
+func (m *Manager) runWithLeaderElection(stopCh <-chan struct{}, run func(stopCh <-chan struct{}) error) error {
+	options := m.options.LeaderElection
+	if err := options.complete(m.options.Component); err != nil {
+		return err
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{Namespace: options.LeaseNamespace, Name: options.LeaseName},
+		Client:    m.KubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      options.Identity,
+			EventRecorder: m.Recorder,
+		},
+	}
+
+	// electionCtx is only cancelled once the controllers have stopped, so
+	// that the Lease is not released while they are still running.
+	electionCtx, cancelElection := context.WithCancel(context.Background())
+	defer cancelElection()
+
+	var mu sync.Mutex
+	var leading, stopping bool
+	var runErr error
+	done := make(chan struct{})
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   options.LeaseDuration,
+		RenewDeadline:   options.RenewDeadline,
+		RetryPeriod:     options.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            options.LeaseName,
+		WatchDog:        m.leaderHealthz,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				defer cancelElection()
+				mu.Lock()
+				if stopping {
+					mu.Unlock()
+					return
+				}
+				leading = true
+				mu.Unlock()
+				defer close(done)
+
+				klog.Infof("Started leading as %s", options.Identity)
+				leaderStopCh := make(chan struct{})
+				go func() {
+					select {
+					case <-stopCh:
+					case <-leaderCtx.Done():
+					}
+					close(leaderStopCh)
+				}()
+				runErr = run(leaderStopCh)
+			},
+			OnStoppedLeading: func() {
+				klog.Infof("Stopped leading as %s", options.Identity)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != options.Identity {
+					klog.Infof("The leader is %s", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to configure leader election")
+	}
+
+	go func() {
+		select {
+		case <-stopCh:
+		case <-electionCtx.Done():
+			return
+		}
+		mu.Lock()
+		stopping = true
+		isLeading := leading
+		mu.Unlock()
+		// The leader cancels the election itself once its controllers have
+		// stopped.
+		if !isLeading {
+			cancelElection()
+		}
+	}()
+
+	klog.Infof("Waiting to become the leader as %s in lease %s/%s", options.Identity, options.LeaseNamespace, options.LeaseName)
+	elector.Run(electionCtx)
+
+	// The election only stops before stopCh is closed if the leadership is
+	// lost, possibly before the controllers were even started.
+	mu.Lock()
+	stopping = true
+	isLeading := leading
+	mu.Unlock()
+	if isLeading {
+		<-done
+	}
+
+	select {
+	case <-stopCh:
+		return runErr
+	default:
+		return utilerrors.NewAggregate([]error{errors.New("lost the leadership"), runErr})
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+)
+
+// runnable is a controller that records when it starts and stops running.
+type runnable struct {
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func newRunnable() *runnable {
+	return &runnable{started: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+func (r *runnable) Run(workers int, stopCh <-chan struct{}) error {
+	close(r.started)
+	<-stopCh
+	close(r.stopped)
+	return nil
+}
+
+// candidate is a Manager running a single runnable with leader election.
+type candidate struct {
+	runnable *runnable
+	stopCh   chan struct{}
+	done     chan error
+}
+
+// startCandidate runs a Manager with leader election as identity against
+// kubeClient.
+func startCandidate(t *testing.T, kubeClient kubernetes.Interface, identity string, leaseDuration, renewDeadline time.Duration) *candidate {
+	m := NewManagerForClients(kubeClient, fake.NewSimpleClientset(), Options{
+		LeaderElection: LeaderElectionOptions{
+			Enabled:        true,
+			LeaseNamespace: "default",
+			Identity:       identity,
+			LeaseDuration:  leaseDuration,
+			RenewDeadline:  renewDeadline,
+			RetryPeriod:    50 * time.Millisecond,
+		},
+	})
+	c := &candidate{runnable: newRunnable(), stopCh: make(chan struct{}), done: make(chan error, 1)}
+	if err := m.Register("test", func(m *Manager) (Runnable, error) { return c.runnable, nil }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	go func() { c.done <- m.Run(c.stopCh) }()
+	return c
+}
+
+func expectClosed(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for %s", what)
+	}
+}
+
+func expectOpen(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+		t.Fatalf("Unexpected %s", what)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func expectDone(t *testing.T, c *candidate, wantErr bool) {
+	t.Helper()
+	select {
+	case err := <-c.done:
+		if (err != nil) != wantErr {
+			t.Errorf("Expected error %t, got %v", wantErr, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for the manager to stop")
+	}
+}
+
+func leaseHolder(t *testing.T, kubeClient kubernetes.Interface) string {
+	t.Helper()
+	lease, err := kubeClient.CoordinationV1().Leases("default").Get(context.TODO(), ComponentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func TestLeaderElection(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	// The lease duration is longer than the test, so the second candidate
+	// can only take over if the first one releases the Lease.
+	first := startCandidate(t, kubeClient, "first", time.Minute, 30*time.Second)
+	expectClosed(t, first.runnable.started, "the leader to start its controllers")
+	if holder := leaseHolder(t, kubeClient); holder != "first" {
+		t.Errorf("Expected the Lease to be held by first, got %q", holder)
+	}
+
+	second := startCandidate(t, kubeClient, "second", time.Minute, 30*time.Second)
+	expectOpen(t, second.runnable.started, "start of the controllers of a candidate that is not the leader")
+
+	close(first.stopCh)
+	expectClosed(t, first.runnable.stopped, "the leader to stop its controllers")
+	expectDone(t, first, false)
+
+	expectClosed(t, second.runnable.started, "the second candidate to take over")
+	if holder := leaseHolder(t, kubeClient); holder != "second" {
+		t.Errorf("Expected the Lease to be held by second, got %q", holder)
+	}
+
+	close(second.stopCh)
+	expectDone(t, second, false)
+	if holder := leaseHolder(t, kubeClient); holder != "" {
+		t.Errorf("Expected the Lease to be released, got holder %q", holder)
+	}
+}
+
+func TestLeaderElectionStopBeforeLeading(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	leader := startCandidate(t, kubeClient, "leader", time.Minute, 30*time.Second)
+	expectClosed(t, leader.runnable.started, "the leader to start its controllers")
+
+	candidate := startCandidate(t, kubeClient, "candidate", time.Minute, 30*time.Second)
+	close(candidate.stopCh)
+	expectDone(t, candidate, false)
+	expectOpen(t, candidate.runnable.started, "start of the controllers of a candidate that was stopped")
+
+	close(leader.stopCh)
+	expectDone(t, leader, false)
+}
+
+func TestLeaderElectionLost(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	// Reactors cannot be added while the client is in use, so the reactor
+	// that makes the renewals of the Lease fail is enabled by a flag.
+	var failUpdates int32
+	kubeClient.PrependReactor("update", "leases", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if atomic.LoadInt32(&failUpdates) == 0 {
+			return false, nil, nil
+		}
+		return true, nil, errors.New("lease update failed")
+	})
+
+	leader := startCandidate(t, kubeClient, "leader", time.Second, 500*time.Millisecond)
+	expectClosed(t, leader.runnable.started, "the leader to start its controllers")
+	atomic.StoreInt32(&failUpdates, 1)
+
+	expectClosed(t, leader.runnable.stopped, "the controllers to stop when the leadership is lost")
+	expectDone(t, leader, true)
+}
+
+func TestLeaderElectionInvalidDurations(t *testing.T) {
+	leader := startCandidate(t, kubefake.NewSimpleClientset(), "leader", time.Second, 2*time.Second)
+	expectDone(t, leader, true)
+	expectOpen(t, leader.runnable.started, "start of the controllers with an invalid configuration")
+}
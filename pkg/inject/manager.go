@@ -0,0 +1,341 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	clusterregistryscheme "k8s.io/cluster-registry/pkg/client/clientset/versioned/scheme"
+	informers "k8s.io/cluster-registry/pkg/client/informers/externalversions"
+)
+
+// ComponentName is the default name of the controller manager in the events
+// it records.
+const ComponentName = "clusterregistry-controller-manager"
+
+// Runnable is a controller run by a Manager, e.g. a
+// k8s.io/cluster-registry/pkg/controller.Controller.
+type Runnable interface {
+	// Run starts workers workers and blocks until stopCh is closed.
+	Run(workers int, stopCh <-chan struct{}) error
+}
+
+// InitFunc creates a controller from the clients, informers and event
+// recorder of m. It must get the informers the controller uses from
+// m.InformerFactory, so that the manager starts them.
+type InitFunc func(m *Manager) (Runnable, error)
+
+// Options are the settings of a Manager.
+type Options struct {
+	// Component names the controller manager in the events it records, and
+	// is the default name of its leader election Lease. Defaults to
+	// ComponentName.
+	Component string
+
+	// Controllers selects the controllers to run: '*' enables all the
+	// controllers, 'foo' enables the controller named 'foo' and '-foo'
+	// disables it. Empty entries are ignored. Defaults to all the controllers.
+	Controllers []string
+
+	// Workers is the number of workers of each controller. Defaults to 1.
+	Workers int
+
+	// ResyncPeriod is the resync period of the shared informers. Zero
+	// disables resyncs.
+	ResyncPeriod time.Duration
+
+	// LeaderElection configures the leader election between the replicas of
+	// the controller manager.
+	LeaderElection LeaderElectionOptions
+
+	// MetricsBindAddress is the address, e.g. ":8080", on which the
+	// Prometheus metrics of the controllers are served at /metrics. Empty
+	// disables the metrics endpoint. The inventory of the clusters is read
+	// from the shared cluster informer, which the Manager then starts even if
+	// no controller uses it.
+	MetricsBindAddress string
+
+	// HealthProbeBindAddress is the address, e.g. ":8081", on which the
+	// liveness and readiness of the controller manager are served at
+	// /healthz and /readyz. It may be the same as MetricsBindAddress. Empty
+	// disables the health endpoints.
+	HealthProbeBindAddress string
+}
+
+// Manager owns the clients and shared informers used by the controllers of
+// the cluster registry, and runs the controllers registered with it.
+type Manager struct {
+	// KubeClient is a clientset for the Kubernetes API of the cluster
+	// hosting the registry.
+	KubeClient kubernetes.Interface
+
+	// ClusterRegistryClient is a clientset for the cluster registry API.
+	ClusterRegistryClient clientset.Interface
+
+	// InformerFactory is the shared informer factory of the cluster registry
+	// API.
+	InformerFactory informers.SharedInformerFactory
+
+	// Recorder records the events of the controllers.
+	Recorder record.EventRecorder
+
+	broadcaster record.EventBroadcaster
+	options     Options
+
+	// initFuncs are the registered controllers, by name.
+	initFuncs map[string]InitFunc
+
+	// leaderHealthz checks that the leader renews its Lease, if leader
+	// election is enabled.
+	leaderHealthz *leaderelection.HealthzAdaptor
+
+	// stateLock guards running and synced, which report whether the
+	// controllers are running, e.g. because m is the leader, and whether the
+	// shared informers have synced since.
+	stateLock sync.Mutex
+	running   bool
+	synced    bool
+}
+
+// NewManager returns a Manager whose clients connect to the API server of
+// config.
+func NewManager(config *rest.Config, options Options) (*Manager, error) {
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the kubernetes clientset")
+	}
+	clusterRegistryClient, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the cluster registry clientset")
+	}
+	return NewManagerForClients(kubeClient, clusterRegistryClient, options), nil
+}
+
+// NewManagerForClients returns a Manager that uses the given clients.
+func NewManagerForClients(kubeClient kubernetes.Interface, clusterRegistryClient clientset.Interface, options Options) *Manager {
+	if options.Workers <= 0 {
+		options.Workers = 1
+	}
+	// Drop the empty entries, e.g. from splitting an empty --controllers
+	// flag.
+	var controllers []string
+	for _, controller := range options.Controllers {
+		if controller != "" {
+			controllers = append(controllers, controller)
+		}
+	}
+	options.Controllers = controllers
+	if len(options.Controllers) == 0 {
+		options.Controllers = []string{"*"}
+	}
+	if options.Component == "" {
+		options.Component = ComponentName
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(kubescheme.AddToScheme(scheme))
+	utilruntime.Must(clusterregistryscheme.AddToScheme(scheme))
+	broadcaster := record.NewBroadcaster()
+
+	var leaderHealthz *leaderelection.HealthzAdaptor
+	if options.LeaderElection.Enabled {
+		leaderHealthz = leaderelection.NewLeaderHealthzAdaptor(DefaultLeaderElectionHealthTimeout)
+	}
+
+	return &Manager{
+		KubeClient:            kubeClient,
+		ClusterRegistryClient: clusterRegistryClient,
+		InformerFactory:       informers.NewSharedInformerFactory(clusterRegistryClient, options.ResyncPeriod),
+		Recorder:              broadcaster.NewRecorder(scheme, corev1.EventSource{Component: options.Component}),
+		broadcaster:           broadcaster,
+		options:               options,
+		initFuncs:             map[string]InitFunc{},
+		leaderHealthz:         leaderHealthz,
+	}
+}
+
+// Register adds the controller named name, created by init, to the
+// controllers of m.
+func (m *Manager) Register(name string, init InitFunc) error {
+	if name == "" || name == "*" || strings.HasPrefix(name, "-") {
+		return errors.Errorf("invalid controller name %q", name)
+	}
+	if _, ok := m.initFuncs[name]; ok {
+		return errors.Errorf("controller %q is already registered", name)
+	}
+	m.initFuncs[name] = init
+	return nil
+}
+
+// ControllerNames returns the sorted names of the registered controllers.
+func (m *Manager) ControllerNames() []string {
+	names := make([]string, 0, len(m.initFuncs))
+	for name := range m.initFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsControllerEnabled returns true if the controller named name is enabled by
+// controllers, as described in Options.Controllers. The first entry naming
+// the controller takes precedence over '*'.
+func IsControllerEnabled(name string, controllers []string) bool {
+	hasStar := false
+	for _, controller := range controllers {
+		if controller == name {
+			return true
+		}
+		if controller == "-"+name {
+			return false
+		}
+		if controller == "*" {
+			hasStar = true
+		}
+	}
+	return hasStar
+}
+
+// enabledControllers returns the names of the enabled controllers, or an
+// error if Options.Controllers refers to an unregistered controller.
+func (m *Manager) enabledControllers() ([]string, error) {
+	for _, controller := range m.options.Controllers {
+		name := strings.TrimPrefix(controller, "-")
+		if _, ok := m.initFuncs[name]; !ok && controller != "*" {
+			return nil, errors.Errorf("unknown controller %q, known controllers are %s", name, strings.Join(m.ControllerNames(), ", "))
+		}
+	}
+	var enabled []string
+	for _, name := range m.ControllerNames() {
+		if IsControllerEnabled(name, m.options.Controllers) {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled, nil
+}
+
+// Run creates the enabled controllers, starts the shared informers, waits for
+// their caches to sync and runs the controllers. It blocks until stopCh is
+// closed and all the controllers have stopped. If leader election is enabled,
+// the informers and controllers are only started once m is the leader, and
+// Run returns an error if m loses the leadership. The metrics and health
+// endpoints, if any, are served for as long as Run runs.
+func (m *Manager) Run(stopCh <-chan struct{}) error {
+	enabled, err := m.enabledControllers()
+	if err != nil {
+		return err
+	}
+
+	controllers := map[string]Runnable{}
+	for _, name := range enabled {
+		klog.Infof("Creating controller %s", name)
+		controller, err := m.initFuncs[name](m)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create controller %s", name)
+		}
+		if controller == nil {
+			klog.Infof("Controller %s is disabled by its configuration", name)
+			continue
+		}
+		controllers[name] = controller
+	}
+
+	stopServing, err := m.serve(controllers)
+	if err != nil {
+		return err
+	}
+	defer stopServing()
+
+	m.broadcaster.StartLogging(klog.Infof)
+	m.broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: m.KubeClient.CoreV1().Events("")})
+	defer m.broadcaster.Shutdown()
+
+	run := func(stopCh <-chan struct{}) error {
+		return m.runControllers(controllers, stopCh)
+	}
+	if m.options.LeaderElection.Enabled {
+		return m.runWithLeaderElection(stopCh, run)
+	}
+	return run(stopCh)
+}
+
+// runControllers starts the shared informers, waits for their caches to sync
+// and runs controllers until stopCh is closed. Closing stopCh before the
+// caches have synced is not an error.
+func (m *Manager) runControllers(controllers map[string]Runnable, stopCh <-chan struct{}) error {
+	m.setState(true, false)
+	defer m.setState(false, false)
+
+	m.InformerFactory.Start(stopCh)
+	klog.Info("Waiting for informer caches to sync")
+	for informerType, synced := range m.InformerFactory.WaitForCacheSync(stopCh) {
+		if !synced {
+			select {
+			case <-stopCh:
+				klog.Info("Stopped before the informer caches synced")
+				return nil
+			default:
+			}
+			return errors.Errorf("failed to wait for the cache of %v to sync", informerType)
+		}
+	}
+	m.setState(true, true)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for name, controller := range controllers {
+		wg.Add(1)
+		go func(name string, controller Runnable) {
+			defer wg.Done()
+			klog.Infof("Starting controller %s", name)
+			if err := controller.Run(m.options.Workers, stopCh); err != nil {
+				mu.Lock()
+				errs = append(errs, errors.Wrapf(err, "controller %s failed", name))
+				mu.Unlock()
+			}
+		}(name, controller)
+	}
+	wg.Wait()
+	return utilerrors.NewAggregate(errs)
+}
+
+// setState records whether the controllers are running and whether the
+// shared informers have synced.
+func (m *Manager) setState(running, synced bool) {
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
+	m.running, m.synced = running, synced
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	"k8s.io/cluster-registry/pkg/controller"
+)
+
+func newManager(controllers ...string) *Manager {
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"}}
+	return NewManagerForClients(kubefake.NewSimpleClientset(), fake.NewSimpleClientset(cluster), Options{Controllers: controllers})
+}
+
+func TestIsControllerEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		controllers []string
+		want        bool
+	}{
+		{"foo", []string{"*"}, true},
+		{"foo", []string{"foo"}, true},
+		{"foo", []string{"bar"}, false},
+		{"foo", []string{"-foo", "*"}, false},
+		{"foo", []string{"*", "-foo"}, false},
+		{"foo", []string{"-bar", "*"}, true},
+		{"foo", []string{}, false},
+	}
+
+	for _, tc := range tests {
+		if got := IsControllerEnabled(tc.name, tc.controllers); got != tc.want {
+			t.Errorf("IsControllerEnabled(%q, %v) = %t, want %t", tc.name, tc.controllers, got, tc.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	m := newManager()
+	init := func(m *Manager) (Runnable, error) { return nil, nil }
+
+	for _, name := range []string{"b", "a"} {
+		if err := m.Register(name, init); err != nil {
+			t.Errorf("Unexpected error registering %q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"a", "", "*", "-c"} {
+		if err := m.Register(name, init); err == nil {
+			t.Errorf("Expected an error registering %q", name)
+		}
+	}
+	if names := m.ControllerNames(); !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("Expected controllers [a b], got %v", names)
+	}
+}
+
+func TestRun(t *testing.T) {
+	m := newManager("*", "-disabled")
+
+	reconciled := make(chan string, 10)
+	if err := m.Register("enabled", func(m *Manager) (Runnable, error) {
+		c := controller.New("enabled", func(key string) (controller.Result, error) {
+			reconciled <- key
+			return controller.Result{}, nil
+		}, controller.Options{})
+		c.WatchClusters(m.InformerFactory.Clusterregistry().V1alpha1().Clusters())
+		return c, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := m.Register("disabled", func(m *Manager) (Runnable, error) {
+		t.Errorf("Expected the disabled controller not to be created")
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := m.Register("unconfigured", func(m *Manager) (Runnable, error) {
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- m.Run(stopCh) }()
+
+	select {
+	case key := <-reconciled:
+		if key != "default/cluster" {
+			t.Errorf("Expected default/cluster to be reconciled, got %s", key)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the enabled controller to reconcile the cluster")
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRunStopDuringCacheSync(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	listing := make(chan struct{})
+	unblock := make(chan struct{})
+	defer close(unblock)
+	var once sync.Once
+	client.PrependReactor("list", "clusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		once.Do(func() { close(listing) })
+		<-unblock
+		return true, nil, errors.New("stopped")
+	})
+	m := NewManagerForClients(kubefake.NewSimpleClientset(), client, Options{})
+	if err := m.Register("controller", func(m *Manager) (Runnable, error) {
+		c := controller.New("controller", func(key string) (controller.Result, error) {
+			return controller.Result{}, nil
+		}, controller.Options{})
+		c.WatchClusters(m.InformerFactory.Clusterregistry().V1alpha1().Clusters())
+		return c, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- m.Run(stopCh) }()
+
+	select {
+	case <-listing:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the cluster informer to list the clusters")
+	}
+	close(stopCh)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected Run to return once stopped")
+	}
+}
+
+func TestEmptyControllers(t *testing.T) {
+	m := newManager("", "")
+	if err := m.Register("controller", func(m *Manager) (Runnable, error) { return nil, nil }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	enabled, err := m.enabledControllers()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(enabled, []string{"controller"}) {
+		t.Errorf("Expected all the controllers to be enabled, got %v", enabled)
+	}
+
+	m = newManager("", "-controller")
+	if err := m.Register("controller", func(m *Manager) (Runnable, error) { return nil, nil }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if enabled, err := m.enabledControllers(); err != nil || len(enabled) != 0 {
+		t.Errorf("Expected no enabled controllers, got %v, %v", enabled, err)
+	}
+}
+
+// freeAddress returns a local address that nothing listens on.
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestRunMetrics(t *testing.T) {
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"}}
+	address := freeAddress(t)
+	m := NewManagerForClients(kubefake.NewSimpleClientset(), fake.NewSimpleClientset(cluster), Options{MetricsBindAddress: address})
+	reconciled := make(chan string, 10)
+	if err := m.Register("metrics", func(m *Manager) (Runnable, error) {
+		c := controller.New("metrics", func(key string) (controller.Result, error) {
+			reconciled <- key
+			return controller.Result{}, nil
+		}, controller.Options{})
+		c.WatchClusters(m.InformerFactory.Clusterregistry().V1alpha1().Clusters())
+		return c, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- m.Run(stopCh) }()
+	select {
+	case <-reconciled:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the controller to reconcile the cluster")
+	}
+
+	// The workqueue and controller metrics are global, and are only
+	// checked to be served.
+	want := []string{
+		`workqueue_adds_total{name="metrics"} `,
+		`clusterregistry_controller_reconcile_total{controller="metrics",result="success"} `,
+		`clusterregistry_clusters{namespace="default",status="Unknown"} 1`,
+	}
+	var body string
+	found := wait.PollImmediate(50*time.Millisecond, 10*time.Second, func() (bool, error) {
+		resp, err := http.Get("http://" + address + "/metrics")
+		if err != nil {
+			return false, nil
+		}
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return false, nil
+		}
+		body = string(data)
+		for _, w := range want {
+			if !strings.Contains(body, w) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if found != nil {
+		t.Errorf("Expected %v in the metrics, got:\n%s", want, body)
+	}
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := http.Get("http://" + address + "/metrics"); err == nil {
+		t.Errorf("Expected the metrics endpoint to stop with the manager")
+	}
+}
+
+func TestRunMetricsAddressInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	m := NewManagerForClients(kubefake.NewSimpleClientset(), fake.NewSimpleClientset(), Options{MetricsBindAddress: listener.Addr().String()})
+	if err := m.Run(make(chan struct{})); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		controllers []string
+		init        InitFunc
+	}{
+		{
+			name:        "unknown controller",
+			controllers: []string{"*", "-unknown"},
+			init:        func(m *Manager) (Runnable, error) { return nil, nil },
+		},
+		{
+			name: "init error",
+			init: func(m *Manager) (Runnable, error) { return nil, errors.New("failed") },
+		},
+		{
+			name: "run error",
+			init: func(m *Manager) (Runnable, error) { return failingRunnable{}, nil },
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newManager(tc.controllers...)
+			if err := m.Register("controller", tc.init); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			if err := m.Run(stopCh); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+type failingRunnable struct{}
+
+func (failingRunnable) Run(workers int, stopCh <-chan struct{}) error {
+	return errors.New("failed")
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/healthz"
+	"k8s.io/cluster-registry/pkg/metrics"
+)
+
+// HealthChecker is implemented by the controllers that report the liveness of
+// their workers at /healthz, e.g. k8s.io/cluster-registry/pkg/controller.Controller.
+type HealthChecker interface {
+	// CheckHealth returns an error if the workers of the controller are
+	// stuck.
+	CheckHealth() error
+}
+
+// serve serves the metrics and health endpoints enabled in the options of m,
+// until the returned function is called. The endpoints share a server if
+// their addresses are the same.
+func (m *Manager) serve(controllers map[string]Runnable) (func(), error) {
+	muxes := map[string]*http.ServeMux{}
+	mux := func(address string) *http.ServeMux {
+		if muxes[address] == nil {
+			muxes[address] = http.NewServeMux()
+		}
+		return muxes[address]
+	}
+
+	if m.options.MetricsBindAddress != "" {
+		informer := m.InformerFactory.Clusterregistry().V1alpha1().Clusters()
+		handler, err := metrics.Handler(metrics.NewClusterCollector(informer.Lister(), informer.Informer().HasSynced))
+		if err != nil {
+			return nil, err
+		}
+		mux(m.options.MetricsBindAddress).Handle("/metrics", handler)
+	}
+	if m.options.HealthProbeBindAddress != "" {
+		mux := mux(m.options.HealthProbeBindAddress)
+		mux.Handle("/healthz", healthz.Handler(m.livenessChecks(controllers)...))
+		mux.Handle("/readyz", healthz.Handler(m.readinessChecks()...))
+	}
+
+	var servers []*http.Server
+	stop := func() {
+		for _, server := range servers {
+			if err := server.Shutdown(context.Background()); err != nil {
+				utilruntime.HandleError(errors.Wrap(err, "failed to shut down the HTTP server"))
+			}
+		}
+	}
+	for address, mux := range muxes {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			stop()
+			return nil, errors.Wrapf(err, "failed to listen on %s", address)
+		}
+		server := &http.Server{Handler: mux}
+		servers = append(servers, server)
+		go func() {
+			klog.Infof("Serving HTTP on %s", listener.Addr())
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				utilruntime.HandleError(errors.Wrapf(err, "failed to serve HTTP on %s", listener.Addr()))
+			}
+		}()
+	}
+	return stop, nil
+}
+
+// livenessChecks returns the checks served at /healthz: the leader must renew
+// its Lease, and the workers of controllers must not be stuck.
+func (m *Manager) livenessChecks(controllers map[string]Runnable) []healthz.Checker {
+	checks := []healthz.Checker{healthz.PingHealthz}
+	if m.leaderHealthz != nil {
+		checks = append(checks, m.leaderHealthz)
+	}
+	var checkers []HealthChecker
+	for _, name := range sortedNames(controllers) {
+		if checker, ok := controllers[name].(HealthChecker); ok {
+			checkers = append(checkers, checker)
+		}
+	}
+	checks = append(checks, healthz.NamedCheck("workers", func(_ *http.Request) error {
+		var errs []error
+		for _, checker := range checkers {
+			if err := checker.CheckHealth(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return utilerrors.NewAggregate(errs)
+	}))
+	return checks
+}
+
+// readinessChecks returns the checks served at /readyz: the shared informers
+// must have synced. A replica that is not the leader does not run the
+// informers, and is ready so that it can take over.
+func (m *Manager) readinessChecks() []healthz.Checker {
+	return []healthz.Checker{
+		healthz.PingHealthz,
+		healthz.NamedCheck("informers", func(_ *http.Request) error {
+			m.stateLock.Lock()
+			defer m.stateLock.Unlock()
+			switch {
+			case m.options.LeaderElection.Enabled && !m.running:
+				return nil
+			case !m.synced:
+				return errors.New("the informer caches have not synced")
+			}
+			return nil
+		}),
+	}
+}
+
+// sortedNames returns the sorted names of controllers.
+func sortedNames(controllers map[string]Runnable) []string {
+	names := make([]string, 0, len(controllers))
+	for name := range controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inject
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+	"k8s.io/cluster-registry/pkg/controller"
+)
+
+// get returns the status and body of the response to a GET of url, or 0 if
+// the request failed.
+func get(url string) (int, string) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return 0, ""
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, ""
+	}
+	return resp.StatusCode, string(body)
+}
+
+// waitForStatus polls url until it responds with status, and returns the last
+// body it responded with.
+func waitForStatus(t *testing.T, url string, status int) string {
+	t.Helper()
+	var lastStatus int
+	var body string
+	if err := wait.PollImmediate(20*time.Millisecond, 10*time.Second, func() (bool, error) {
+		lastStatus, body = get(url)
+		return lastStatus == status, nil
+	}); err != nil {
+		t.Fatalf("Expected status %d from %s, got %d:\n%s", status, url, lastStatus, body)
+	}
+	return body
+}
+
+func TestRunHealth(t *testing.T) {
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster"}}
+	address := freeAddress(t)
+	m := NewManagerForClients(kubefake.NewSimpleClientset(), fake.NewSimpleClientset(cluster), Options{
+		MetricsBindAddress:     address,
+		HealthProbeBindAddress: address,
+	})
+	release := make(chan struct{})
+	if err := m.Register("health", func(m *Manager) (Runnable, error) {
+		c := controller.New("health", func(key string) (controller.Result, error) {
+			<-release
+			return controller.Result{}, nil
+		}, controller.Options{MaxReconcileDuration: 50 * time.Millisecond})
+		c.WatchClusters(m.InformerFactory.Clusterregistry().V1alpha1().Clusters())
+		return c, nil
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- m.Run(stopCh) }()
+
+	waitForStatus(t, "http://"+address+"/readyz", http.StatusOK)
+	body := waitForStatus(t, "http://"+address+"/healthz", http.StatusInternalServerError)
+	if !strings.Contains(body, "[-]workers failed") || !strings.Contains(body, "default/cluster") {
+		t.Errorf("Expected the stuck worker to be reported, got:\n%s", body)
+	}
+	if status, _ := get("http://" + address + "/metrics"); status != http.StatusOK {
+		t.Errorf("Expected the metrics to be served on the same address, got status %d", status)
+	}
+
+	close(release)
+	waitForStatus(t, "http://"+address+"/healthz", http.StatusOK)
+
+	close(stopCh)
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if status, _ := get("http://" + address + "/healthz"); status != 0 {
+		t.Errorf("Expected the health endpoints to stop with the manager, got status %d", status)
+	}
+}
+
+func TestReadinessChecks(t *testing.T) {
+	tests := []struct {
+		name           string
+		leaderElection bool
+		running        bool
+		synced         bool
+		wantReady      bool
+	}{
+		{name: "starting"},
+		{name: "syncing", running: true},
+		{name: "synced", running: true, synced: true, wantReady: true},
+		{name: "standby", leaderElection: true, wantReady: true},
+		{name: "leader syncing", leaderElection: true, running: true},
+		{name: "leader synced", leaderElection: true, running: true, synced: true, wantReady: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewManagerForClients(kubefake.NewSimpleClientset(), fake.NewSimpleClientset(), Options{
+				LeaderElection: LeaderElectionOptions{Enabled: tc.leaderElection},
+			})
+			m.setState(tc.running, tc.synced)
+			ready := true
+			for _, check := range m.readinessChecks() {
+				if check.Check(nil) != nil {
+					ready = false
+				}
+			}
+			if ready != tc.wantReady {
+				t.Errorf("Expected ready %t, got %t", tc.wantReady, ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeconfig converts between Clusters in the cluster registry and
+// kubeconfig files.
+package kubeconfig
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	"k8s.io/cluster-registry/pkg/clusterclient"
+)
+
+// ExportOptions control how Clusters are rendered into a kubeconfig.
+type ExportOptions struct {
+	// ClientIP is used to select the server address of each cluster. If nil,
+	// the addresses of the local host are used.
+	ClientIP net.IP
+
+	// EmbedCredentials makes Export resolve the AuthInfo.Controller of each
+	// cluster through KubeClient and embed the resulting credentials in the
+	// kubeconfig. Otherwise, the kubeconfig contains no credentials.
+	EmbedCredentials bool
+
+	// KubeClient is the client of the registry's Kubernetes API server. It is
+	// only required if EmbedCredentials is set.
+	KubeClient kubernetes.Interface
+}
+
+// EntryName returns the name of the cluster, context and, if credentials are
+// embedded, user entries generated for cluster in an exported kubeconfig.
+func EntryName(cluster *v1alpha1.Cluster) string {
+	return cluster.Namespace + "/" + cluster.Name
+}
+
+// ExportFromRegistry lists the Clusters in namespace that match selector
+// through client, and renders them into a kubeconfig with Export. An empty
+// namespace selects all namespaces.
+func ExportFromRegistry(client clientset.Interface, namespace string, selector labels.Selector, options ExportOptions) (*clientcmdapi.Config, error) {
+	list, err := client.ClusterregistryV1alpha1().Clusters(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list clusters")
+	}
+	clusters := make([]*v1alpha1.Cluster, len(list.Items))
+	for i := range list.Items {
+		clusters[i] = &list.Items[i]
+	}
+	return Export(clusters, options)
+}
+
+// Export renders clusters into a kubeconfig with one cluster and one context
+// entry per Cluster, named by EntryName. Each cluster entry uses the server
+// address selected by options.ClientIP and the CABundle of the Cluster. If
+// options.EmbedCredentials is set, each context also references a user entry
+// holding the credentials resolved by clusterclient.RESTConfigForCluster; the
+// clusters whose credentials cannot be resolved, e.g. because they have no
+// AuthInfo.Controller, are logged and exported without a user entry.
+//
+// The current context is left unset.
+func Export(clusters []*v1alpha1.Cluster, options ExportOptions) (*clientcmdapi.Config, error) {
+	if options.EmbedCredentials && options.KubeClient == nil {
+		return nil, errors.New("a Kubernetes client is required to embed credentials")
+	}
+
+	config := clientcmdapi.NewConfig()
+	for _, cluster := range clusters {
+		name := EntryName(cluster)
+
+		context := clientcmdapi.NewContext()
+		context.Cluster = name
+
+		if options.EmbedCredentials {
+			// A cluster whose credentials cannot be resolved is still
+			// exported, without a user entry.
+			restConfig, err := clusterclient.RESTConfigForCluster(cluster, options.KubeClient, options.ClientIP)
+			if err != nil {
+				klog.Warningf("Exporting cluster %s without credentials: %v", name, err)
+			} else {
+				authInfo := clientcmdapi.NewAuthInfo()
+				authInfo.Token = restConfig.BearerToken
+				authInfo.ClientCertificateData = restConfig.TLSClientConfig.CertData
+				authInfo.ClientKeyData = restConfig.TLSClientConfig.KeyData
+				config.AuthInfos[name] = authInfo
+				context.AuthInfo = name
+			}
+		}
+
+		server, err := serverForCluster(cluster, options.ClientIP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cluster %s", name)
+		}
+		kubeCluster := clientcmdapi.NewCluster()
+		kubeCluster.Server = server
+		kubeCluster.CertificateAuthorityData = cluster.Spec.KubernetesAPIEndpoints.CABundle
+
+		config.Clusters[name] = kubeCluster
+		config.Contexts[name] = context
+	}
+	return config, nil
+}
+
+// serverForCluster returns the URL of the server of cluster that a client
+// with clientIP, or the local host if clientIP is nil, should use.
+func serverForCluster(cluster *v1alpha1.Cluster, clientIP net.IP) (string, error) {
+	address, err := helper.ServerAddressForClient(cluster, clientIP)
+	if err != nil {
+		return "", err
+	}
+	u, err := helper.ServerURL(address)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
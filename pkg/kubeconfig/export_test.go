@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"net"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+)
+
+func newCluster(namespace, name, address string, labels map[string]string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesAPIEndpoints: v1alpha1.KubernetesAPIEndpoints{
+				ServerEndpoints: []v1alpha1.ServerAddressByClientCIDR{
+					{ClientCIDR: "0.0.0.0/0", ServerAddress: address},
+				},
+				CABundle: []byte("ca-" + name),
+			},
+			AuthInfo: v1alpha1.AuthInfo{
+				Controller: &v1alpha1.ObjectReference{Kind: "Secret", Name: name + "-creds"},
+			},
+		},
+	}
+}
+
+func TestExportFromRegistry(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newCluster("prod", "one", "one.example.com", map[string]string{"env": "prod"}),
+		newCluster("prod", "two", "two.example.com:6443", map[string]string{"env": "prod"}),
+		newCluster("prod", "test", "test.example.com", map[string]string{"env": "test"}),
+		newCluster("other", "three", "three.example.com", map[string]string{"env": "prod"}),
+	)
+	selector := labels.SelectorFromSet(labels.Set{"env": "prod"})
+
+	config, err := ExportFromRegistry(client, "prod", selector, ExportOptions{ClientIP: net.ParseIP("10.0.0.1")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Clusters) != 2 || len(config.Contexts) != 2 {
+		t.Fatalf("Expected 2 clusters and contexts, got %v and %v", config.Clusters, config.Contexts)
+	}
+	if len(config.AuthInfos) != 0 {
+		t.Errorf("Expected no users, got %v", config.AuthInfos)
+	}
+	cluster := config.Clusters["prod/two"]
+	if cluster == nil || cluster.Server != "https://two.example.com:6443" || string(cluster.CertificateAuthorityData) != "ca-two" {
+		t.Errorf("Unexpected cluster entry for prod/two: %#v", cluster)
+	}
+	if context := config.Contexts["prod/one"]; context == nil || context.Cluster != "prod/one" || context.AuthInfo != "" {
+		t.Errorf("Unexpected context entry for prod/one: %#v", context)
+	}
+}
+
+func TestExportEmbedCredentials(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "one-creds", Namespace: "prod"},
+		Data:       map[string][]byte{"token": []byte("abc")},
+	})
+	options := ExportOptions{ClientIP: net.ParseIP("10.0.0.1"), EmbedCredentials: true, KubeClient: kubeClient}
+
+	cluster := newCluster("prod", "one", "one.example.com", nil)
+	cluster.Spec.KubernetesAPIEndpoints.CABundle = nil
+
+	config, err := Export([]*v1alpha1.Cluster{cluster}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user := config.AuthInfos["prod/one"]; user == nil || user.Token != "abc" {
+		t.Errorf("Expected a user with token %q, got %#v", "abc", user)
+	}
+	if context := config.Contexts["prod/one"]; context == nil || context.AuthInfo != "prod/one" {
+		t.Errorf("Expected the context to reference the user, got %#v", context)
+	}
+
+	// Clusters whose credentials are missing, or that have no controller
+	// reference, are exported without a user.
+	two := newCluster("prod", "two", "two.example.com", nil)
+	three := newCluster("prod", "three", "three.example.com", nil)
+	three.Spec.AuthInfo.Controller = nil
+	for _, c := range []*v1alpha1.Cluster{two, three} {
+		c.Spec.KubernetesAPIEndpoints.CABundle = nil
+	}
+	config, err = Export([]*v1alpha1.Cluster{cluster, two, three}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Clusters) != 3 || len(config.Contexts) != 3 {
+		t.Errorf("Expected 3 clusters and contexts, got %v and %v", config.Clusters, config.Contexts)
+	}
+	if len(config.AuthInfos) != 1 || config.AuthInfos["prod/one"] == nil {
+		t.Errorf("Expected only the user of prod/one, got %v", config.AuthInfos)
+	}
+	for _, name := range []string{"prod/two", "prod/three"} {
+		if context := config.Contexts[name]; context == nil || context.AuthInfo != "" {
+			t.Errorf("Expected the context %s without a user, got %#v", name, context)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	clientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	"k8s.io/cluster-registry/pkg/clusterclient"
+)
+
+// ImportOptions control how kubeconfig contexts are imported into the
+// cluster registry.
+type ImportOptions struct {
+	// Namespace is the namespace in which Clusters and Secrets are created.
+	Namespace string
+
+	// CreateSecrets makes Import store the static credentials (bearer token or
+	// client certificate and key) of each context in a Secret, and reference
+	// it from the AuthInfo.Controller of the Cluster.
+	CreateSecrets bool
+
+	// KubeClient is the client of the registry's Kubernetes API server. It is
+	// only required if CreateSecrets is set.
+	KubeClient kubernetes.Interface
+
+	// Overwrite makes Import update existing Clusters and Secrets that differ
+	// from the imported ones. Otherwise, such differences are reported as
+	// conflicts and the existing objects are left untouched.
+	Overwrite bool
+
+	// DryRun makes Import report what it would do without writing anything.
+	DryRun bool
+}
+
+// ImportAction is the outcome of importing a single kubeconfig context.
+type ImportAction string
+
+const (
+	// ImportCreated means that the Cluster was created.
+	ImportCreated ImportAction = "created"
+	// ImportUpdated means that an existing Cluster was updated.
+	ImportUpdated ImportAction = "updated"
+	// ImportUnchanged means that an identical Cluster already existed.
+	ImportUnchanged ImportAction = "unchanged"
+	// ImportConflict means that a different Cluster or Secret already existed
+	// and Overwrite was not set, or that another context imported in the same
+	// call maps to the same Cluster.
+	ImportConflict ImportAction = "conflict"
+	// ImportFailed means that the context could not be imported.
+	ImportFailed ImportAction = "failed"
+)
+
+// ImportResult reports the outcome of importing a single kubeconfig context.
+type ImportResult struct {
+	// Context is the name of the imported kubeconfig context.
+	Context string
+	// Cluster is the namespace/name of the Cluster.
+	Cluster string
+	// Action is the outcome of the import.
+	Action ImportAction
+	// Message gives details about the outcome, if any.
+	Message string
+}
+
+func (r ImportResult) String() string {
+	s := fmt.Sprintf("context %q: cluster %s %s", r.Context, r.Cluster, r.Action)
+	if r.Message != "" {
+		s += ": " + r.Message
+	}
+	return s
+}
+
+// ImportFiles loads the kubeconfig files at paths and imports them with
+// Import. The relative paths of the files referenced by a kubeconfig file,
+// e.g. its certificate authorities, are relative to its directory. A context
+// that maps to the same Cluster as a context of an earlier file is reported
+// as a conflict.
+func ImportFiles(client clientset.Interface, paths []string, options ImportOptions) ([]ImportResult, error) {
+	var results []ImportResult
+	imported := map[string]string{}
+	for _, path := range paths {
+		config, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			return results, errors.Wrapf(err, "failed to load kubeconfig %s", path)
+		}
+		// Relative file references are relative to the kubeconfig file.
+		if err := clientcmd.ResolveLocalPaths(config); err != nil {
+			return results, errors.Wrapf(err, "failed to resolve the paths in kubeconfig %s", path)
+		}
+		results = append(results, importConfig(client, config, options, imported)...)
+	}
+	return results, nil
+}
+
+// Import creates or updates one Cluster through client for each context in
+// config, in the order of their names. The Cluster is named after the
+// context, with characters that are not allowed in object names replaced by
+// dashes. Its only server endpoint uses the server of the context's cluster
+// with a 0.0.0.0/0 ClientCIDR, and its CABundle is the certificate authority
+// of the context's cluster.
+//
+// Several contexts may map to the same Cluster name, e.g. "Prod_A" and
+// "prod-a": only the first one is imported, and the others are reported as
+// conflicts, even if Overwrite is set.
+//
+// Failures to import a context are reported in its result and do not prevent
+// the other contexts from being imported.
+func Import(client clientset.Interface, config *clientcmdapi.Config, options ImportOptions) []ImportResult {
+	return importConfig(client, config, options, map[string]string{})
+}
+
+// importConfig imports the contexts of config like Import. imported maps the
+// names of the Clusters imported so far to the contexts they were imported
+// from, and is updated with the contexts of config.
+func importConfig(client clientset.Interface, config *clientcmdapi.Config, options ImportOptions, imported map[string]string) []ImportResult {
+	contextNames := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contextNames = append(contextNames, name)
+	}
+	sort.Strings(contextNames)
+
+	results := make([]ImportResult, 0, len(contextNames))
+	for _, contextName := range contextNames {
+		name := ClusterNameForContext(contextName)
+		result := ImportResult{Context: contextName, Cluster: options.Namespace + "/" + name}
+		if previous, ok := imported[name]; ok {
+			result.Action = ImportConflict
+			result.Message = fmt.Sprintf("context %q already maps to this Cluster", previous)
+			results = append(results, result)
+			continue
+		}
+		action, message, err := importContext(client, config, contextName, options)
+		if err != nil {
+			action, message = ImportFailed, err.Error()
+		}
+		if action != ImportFailed {
+			imported[name] = contextName
+		}
+		result.Action, result.Message = action, message
+		results = append(results, result)
+	}
+	return results
+}
+
+// invalidNameChars matches the characters that are not allowed in a DNS-1123
+// subdomain.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ClusterNameForContext returns the name of the Cluster that Import creates
+// for the kubeconfig context with the given name.
+func ClusterNameForContext(contextName string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(contextName), "-")
+	return strings.Trim(name, "-.")
+}
+
+func importContext(client clientset.Interface, config *clientcmdapi.Config, contextName string, options ImportOptions) (ImportAction, string, error) {
+	kubeContext := config.Contexts[contextName]
+	kubeCluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return "", "", errors.Errorf("cluster %q not found in kubeconfig", kubeContext.Cluster)
+	}
+
+	name := ClusterNameForContext(contextName)
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", "", errors.Errorf("invalid cluster name %q: %s", name, strings.Join(errs, ", "))
+	}
+
+	cluster, err := clusterFromKubeconfig(kubeCluster)
+	if err != nil {
+		return "", "", err
+	}
+	cluster.Name, cluster.Namespace = name, options.Namespace
+
+	var secret *corev1.Secret
+	if options.CreateSecrets {
+		if options.KubeClient == nil {
+			return "", "", errors.New("a Kubernetes client is required to create secrets")
+		}
+		authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+		if !ok {
+			return "", "", errors.Errorf("user %q not found in kubeconfig", kubeContext.AuthInfo)
+		}
+		if secret, err = secretFromKubeconfig(authInfo); err != nil {
+			return "", "", err
+		}
+		secret.Name, secret.Namespace = name+"-credentials", options.Namespace
+		cluster.Spec.AuthInfo.Controller = &v1alpha1.ObjectReference{
+			Kind:      "Secret",
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+		}
+	}
+
+	clusters := client.ClusterregistryV1alpha1().Clusters(options.Namespace)
+	existing, err := clusters.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", "", err
+	}
+	clusterExists := err == nil
+
+	// Decide what to do with the Cluster before writing anything, so that a
+	// conflict leaves no Secret behind.
+	action := ImportCreated
+	if clusterExists {
+		// Keep the existing controller credentials unless new ones are
+		// imported.
+		if secret == nil {
+			cluster.Spec.AuthInfo.Controller = existing.Spec.AuthInfo.Controller
+		}
+		cluster.Spec.AuthInfo.User = existing.Spec.AuthInfo.User
+		switch {
+		case apiequality.Semantic.DeepEqual(existing.Spec, cluster.Spec):
+			action = ImportUnchanged
+		case !options.Overwrite:
+			return ImportConflict, "a Cluster with a different spec already exists", nil
+		default:
+			action = ImportUpdated
+		}
+	}
+
+	secretCreated := false
+	if secret != nil {
+		created, conflict, err := applySecret(options.KubeClient, secret, options)
+		if err != nil {
+			return "", "", err
+		}
+		if conflict {
+			return ImportConflict, fmt.Sprintf("a different Secret %s already exists", secret.Name), nil
+		}
+		secretCreated = created && !options.DryRun
+	}
+
+	var message string
+	if options.DryRun {
+		message = "dry run"
+	}
+	if options.DryRun || action == ImportUnchanged {
+		return action, message, nil
+	}
+
+	if clusterExists {
+		existing = existing.DeepCopy()
+		existing.Spec = cluster.Spec
+		_, err = clusters.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	} else {
+		_, err = clusters.Create(context.TODO(), cluster, metav1.CreateOptions{})
+	}
+	if err != nil && secretCreated {
+		// Do not leave behind a Secret that no Cluster references.
+		if deleteErr := options.KubeClient.CoreV1().Secrets(secret.Namespace).Delete(context.TODO(), secret.Name, metav1.DeleteOptions{}); deleteErr != nil {
+			return "", "", errors.Wrapf(err, "failed to write the Cluster, and to delete the Secret %s created for it (%v)", secret.Name, deleteErr)
+		}
+	}
+	return action, message, err
+}
+
+// applySecret creates secret, or updates the existing Secret with the same
+// name if it differs and options.Overwrite is set. It returns whether secret
+// did not exist and was created, and whether a different Secret exists and
+// options.Overwrite is not set.
+func applySecret(kubeClient kubernetes.Interface, secret *corev1.Secret, options ImportOptions) (created, conflict bool, err error) {
+	secrets := kubeClient.CoreV1().Secrets(secret.Namespace)
+	existing, err := secrets.Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if !options.DryRun {
+			_, err = secrets.Create(context.TODO(), secret, metav1.CreateOptions{})
+		} else {
+			err = nil
+		}
+		return err == nil, false, err
+	case err != nil:
+		return false, false, err
+	case apiequality.Semantic.DeepEqual(existing.Data, secret.Data):
+		return false, false, nil
+	case !options.Overwrite:
+		return false, true, nil
+	case options.DryRun:
+		return false, false, nil
+	}
+	existing = existing.DeepCopy()
+	existing.Data = secret.Data
+	_, err = secrets.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	return false, false, err
+}
+
+// clusterFromKubeconfig returns a Cluster whose spec is derived from
+// kubeCluster.
+func clusterFromKubeconfig(kubeCluster *clientcmdapi.Cluster) (*v1alpha1.Cluster, error) {
+	if kubeCluster.Server == "" {
+		return nil, errors.New("cluster has no server")
+	}
+	address := kubeCluster.Server
+	if u, err := url.Parse(address); err == nil && u.Scheme == "https" && strings.Trim(u.Path, "/") == "" {
+		// Server addresses default to HTTPS, so only keep the host and port.
+		address = u.Host
+	}
+
+	caBundle := kubeCluster.CertificateAuthorityData
+	if len(caBundle) == 0 && kubeCluster.CertificateAuthority != "" {
+		var err error
+		if caBundle, err = ioutil.ReadFile(kubeCluster.CertificateAuthority); err != nil {
+			return nil, errors.Wrap(err, "failed to read certificate authority")
+		}
+	}
+
+	cluster := &v1alpha1.Cluster{}
+	cluster.Spec.KubernetesAPIEndpoints = v1alpha1.KubernetesAPIEndpoints{
+		ServerEndpoints: []v1alpha1.ServerAddressByClientCIDR{{
+			ClientCIDR:    "0.0.0.0/0",
+			ServerAddress: address,
+		}},
+		CABundle: caBundle,
+	}
+	return cluster, nil
+}
+
+// secretFromKubeconfig returns a Secret holding the static credentials of
+// authInfo, in the format expected by clusterclient.RESTConfigForCluster.
+func secretFromKubeconfig(authInfo *clientcmdapi.AuthInfo) (*corev1.Secret, error) {
+	data := map[string][]byte{}
+
+	token := []byte(authInfo.Token)
+	if len(token) == 0 && authInfo.TokenFile != "" {
+		var err error
+		if token, err = ioutil.ReadFile(authInfo.TokenFile); err != nil {
+			return nil, errors.Wrap(err, "failed to read token")
+		}
+	}
+	if len(token) > 0 {
+		data[clusterclient.SecretTokenKey] = token
+	}
+
+	cert, err := dataOrFile(authInfo.ClientCertificateData, authInfo.ClientCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client certificate")
+	}
+	key, err := dataOrFile(authInfo.ClientKeyData, authInfo.ClientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read client key")
+	}
+	if len(cert) > 0 && len(key) > 0 {
+		data[corev1.TLSCertKey] = cert
+		data[corev1.TLSPrivateKeyKey] = key
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("user has no static credentials (token or client certificate and key)")
+	}
+	return &corev1.Secret{Type: corev1.SecretTypeOpaque, Data: data}, nil
+}
+
+func dataOrFile(data []byte, path string) ([]byte, error) {
+	if len(data) > 0 || path == "" {
+		return data, nil
+	}
+	return ioutil.ReadFile(path)
+}
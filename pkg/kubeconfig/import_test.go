@@ -0,0 +1,259 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"k8s.io/cluster-registry/pkg/client/clientset/versioned/fake"
+)
+
+func newKubeconfig(server string) *clientcmdapi.Config {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["c1"] = &clientcmdapi.Cluster{Server: server, CertificateAuthorityData: []byte("ca")}
+	config.AuthInfos["u1"] = &clientcmdapi.AuthInfo{Token: "abc"}
+	config.AuthInfos["u2"] = &clientcmdapi.AuthInfo{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "gcp"}}
+	config.Contexts["gke_project_zone_Prod"] = &clientcmdapi.Context{Cluster: "c1", AuthInfo: "u1"}
+	config.Contexts["missing-cluster"] = &clientcmdapi.Context{Cluster: "c2", AuthInfo: "u1"}
+	return config
+}
+
+func actions(results []ImportResult) map[string]ImportAction {
+	actions := map[string]ImportAction{}
+	for _, result := range results {
+		actions[result.Cluster] = result.Action
+	}
+	return actions
+}
+
+func TestImport(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	options := ImportOptions{Namespace: "default"}
+
+	results := Import(client, newKubeconfig("https://1.2.3.4"), options)
+	if got := actions(results); got["default/gke-project-zone-prod"] != ImportCreated || got["default/missing-cluster"] != ImportFailed {
+		t.Fatalf("Unexpected results: %v", results)
+	}
+	cluster, err := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "gke-project-zone-prod", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	endpoints := cluster.Spec.KubernetesAPIEndpoints
+	if len(endpoints.ServerEndpoints) != 1 || endpoints.ServerEndpoints[0].ServerAddress != "1.2.3.4" ||
+		endpoints.ServerEndpoints[0].ClientCIDR != "0.0.0.0/0" || string(endpoints.CABundle) != "ca" {
+		t.Errorf("Unexpected endpoints: %#v", endpoints)
+	}
+
+	results = Import(client, newKubeconfig("https://1.2.3.4"), options)
+	if got := actions(results)["default/gke-project-zone-prod"]; got != ImportUnchanged {
+		t.Errorf("Expected %q, got %v", ImportUnchanged, results)
+	}
+
+	results = Import(client, newKubeconfig("https://5.6.7.8:6443"), options)
+	if got := actions(results)["default/gke-project-zone-prod"]; got != ImportConflict {
+		t.Errorf("Expected %q, got %v", ImportConflict, results)
+	}
+
+	options.Overwrite, options.DryRun = true, true
+	results = Import(client, newKubeconfig("https://5.6.7.8:6443"), options)
+	if got := actions(results)["default/gke-project-zone-prod"]; got != ImportUpdated {
+		t.Errorf("Expected %q, got %v", ImportUpdated, results)
+	}
+	cluster, _ = client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "gke-project-zone-prod", metav1.GetOptions{})
+	if address := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ServerAddress; address != "1.2.3.4" {
+		t.Errorf("Expected a dry run not to update the cluster, got server address %q", address)
+	}
+
+	options.DryRun = false
+	Import(client, newKubeconfig("https://5.6.7.8:6443"), options)
+	cluster, _ = client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "gke-project-zone-prod", metav1.GetOptions{})
+	if address := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ServerAddress; address != "5.6.7.8:6443" {
+		t.Errorf("Expected server address %q, got %q", "5.6.7.8:6443", address)
+	}
+}
+
+func TestImportCreateSecrets(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	config := newKubeconfig("https://proxy.example.com/k8s/clusters/c1")
+	config.Contexts["no-static-credentials"] = &clientcmdapi.Context{Cluster: "c1", AuthInfo: "u2"}
+	options := ImportOptions{Namespace: "registry", CreateSecrets: true, KubeClient: kubeClient}
+
+	results := Import(client, config, options)
+	if got := actions(results); got["registry/gke-project-zone-prod"] != ImportCreated || got["registry/no-static-credentials"] != ImportFailed {
+		t.Fatalf("Unexpected results: %v", results)
+	}
+
+	cluster, err := client.ClusterregistryV1alpha1().Clusters("registry").Get(context.TODO(), "gke-project-zone-prod", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if address := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ServerAddress; address != "https://proxy.example.com/k8s/clusters/c1" {
+		t.Errorf("Expected the full server URL to be kept, got %q", address)
+	}
+	ref := cluster.Spec.AuthInfo.Controller
+	if ref == nil || ref.Kind != "Secret" || ref.Name != "gke-project-zone-prod-credentials" || ref.Namespace != "registry" {
+		t.Fatalf("Unexpected controller auth info: %#v", ref)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("registry").Get(context.TODO(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(secret.Data["token"]) != "abc" {
+		t.Errorf("Expected token %q, got %q", "abc", secret.Data["token"])
+	}
+}
+
+func TestImportDuplicateNames(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	config := newKubeconfig("https://1.2.3.4")
+	config.Clusters["c2"] = &clientcmdapi.Cluster{Server: "https://5.6.7.8"}
+	config.Contexts["Prod_A"] = &clientcmdapi.Context{Cluster: "c1"}
+	config.Contexts["prod-a"] = &clientcmdapi.Context{Cluster: "c2"}
+
+	for _, overwrite := range []bool{false, true} {
+		results := Import(client, config, ImportOptions{Namespace: "default", Overwrite: overwrite})
+		var prodA []ImportResult
+		for _, result := range results {
+			if result.Cluster == "default/prod-a" {
+				prodA = append(prodA, result)
+			}
+		}
+		if len(prodA) != 2 || prodA[0].Context != "Prod_A" || prodA[0].Action == ImportConflict || prodA[1].Action != ImportConflict {
+			t.Errorf("Expected only the second context of default/prod-a to conflict (overwrite %t), got %v", overwrite, prodA)
+		}
+	}
+	cluster, err := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "prod-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if address := cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ServerAddress; address != "1.2.3.4" {
+		t.Errorf("Expected the cluster of the first context, got server address %q", address)
+	}
+}
+
+func TestImportFilesDuplicateNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// kubeadm names the context of every cluster the same way.
+	var paths []string
+	for i, server := range []string{"https://1.2.3.4", "https://5.6.7.8"} {
+		config := clientcmdapi.NewConfig()
+		config.Clusters["kubernetes"] = &clientcmdapi.Cluster{Server: server}
+		config.Contexts["kubernetes-admin@kubernetes"] = &clientcmdapi.Context{Cluster: "kubernetes"}
+		path := filepath.Join(dir, fmt.Sprintf("config-%d", i))
+		if err := clientcmd.WriteToFile(*config, path); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	client := fake.NewSimpleClientset()
+	results, err := ImportFiles(client, paths, ImportOptions{Namespace: "default", Overwrite: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Action != ImportCreated || results[1].Action != ImportConflict {
+		t.Errorf("Expected the second file to conflict with the first, got %v", results)
+	}
+}
+
+func TestImportDeletesSecretOnFailure(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "clusters", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("create failed")
+	})
+	kubeClient := kubefake.NewSimpleClientset()
+	options := ImportOptions{Namespace: "registry", CreateSecrets: true, KubeClient: kubeClient}
+
+	results := Import(client, newKubeconfig("https://1.2.3.4"), options)
+	if got := actions(results)["registry/gke-project-zone-prod"]; got != ImportFailed {
+		t.Fatalf("Expected %q, got %v", ImportFailed, results)
+	}
+	if _, err := kubeClient.CoreV1().Secrets("registry").Get(context.TODO(), "gke-project-zone-prod-credentials", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the Secret to be deleted, got %v", err)
+	}
+}
+
+func TestImportFilesRelativePaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{"ca.crt": "ca", "token": "abc"}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	config := clientcmdapi.NewConfig()
+	config.Clusters["c1"] = &clientcmdapi.Cluster{Server: "https://1.2.3.4", CertificateAuthority: "ca.crt"}
+	config.AuthInfos["u1"] = &clientcmdapi.AuthInfo{TokenFile: "token"}
+	config.Contexts["relative"] = &clientcmdapi.Context{Cluster: "c1", AuthInfo: "u1"}
+	path := filepath.Join(dir, "config")
+	if err := clientcmd.WriteToFile(*config, path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The files are resolved relative to the kubeconfig, not to the current
+	// directory.
+	client := fake.NewSimpleClientset()
+	kubeClient := kubefake.NewSimpleClientset()
+	options := ImportOptions{Namespace: "default", CreateSecrets: true, KubeClient: kubeClient}
+	results, err := ImportFiles(client, []string{path}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := actions(results)["default/relative"]; got != ImportCreated {
+		t.Fatalf("Expected %q, got %v", ImportCreated, results)
+	}
+	cluster, err := client.ClusterregistryV1alpha1().Clusters("default").Get(context.TODO(), "relative", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if caBundle := string(cluster.Spec.KubernetesAPIEndpoints.CABundle); caBundle != "ca" {
+		t.Errorf("Expected CA bundle %q, got %q", "ca", caBundle)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "relative-credentials", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(secret.Data["token"]) != "abc" {
+		t.Errorf("Expected token %q, got %q", "abc", secret.Data["token"])
+	}
+}
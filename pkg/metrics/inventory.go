@@ -0,0 +1,87 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/helper"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+)
+
+var clustersDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(Namespace, "", "clusters"),
+	"Number of clusters in the registry, by namespace and by status of their OK condition. "+
+		"Clusters without an OK condition have the status Unknown.",
+	[]string{"namespace", "status"}, nil)
+
+// clusterCollector counts the clusters in the cache of an informer.
+type clusterCollector struct {
+	lister listers.ClusterLister
+	synced cache.InformerSynced
+}
+
+// NewClusterCollector returns a collector of the inventory of the clusters
+// listed by lister. It reports no inventory until synced returns true, so
+// that the clusters are not undercounted while the informer of lister is
+// starting, or when it is not running, e.g. in a controller manager that is
+// not the leader.
+func NewClusterCollector(lister listers.ClusterLister, synced cache.InformerSynced) prometheus.Collector {
+	return &clusterCollector{lister: lister, synced: synced}
+}
+
+// Describe implements prometheus.Collector.
+func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clustersDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.synced() {
+		return
+	}
+	clusters, err := c.lister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+
+	type key struct{ namespace, status string }
+	counts := map[key]int{}
+	for _, cluster := range clusters {
+		counts[key{cluster.Namespace, clusterOKStatus(cluster)}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(clustersDesc, prometheus.GaugeValue, float64(count), k.namespace, k.status)
+	}
+}
+
+// clusterOKStatus returns the status of the OK condition of cluster, or
+// Unknown if it has none.
+func clusterOKStatus(cluster *v1alpha1.Cluster) string {
+	condition := helper.GetClusterCondition(&cluster.Status, v1alpha1.ClusterOK)
+	if condition == nil || condition.Status == "" {
+		return string(corev1.ConditionUnknown)
+	}
+	return string(condition.Status)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	listers "k8s.io/cluster-registry/pkg/client/listers/clusterregistry/v1alpha1"
+)
+
+func newCluster(namespace, name string, ok corev1.ConditionStatus) *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if ok != "" {
+		cluster.Status.Conditions = []v1alpha1.ClusterCondition{{Type: v1alpha1.ClusterOK, Status: ok}}
+	}
+	return cluster
+}
+
+func newLister(t *testing.T, clusters ...*v1alpha1.Cluster) listers.ClusterLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, cluster := range clusters {
+		if err := indexer.Add(cluster); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	return listers.NewClusterLister(indexer)
+}
+
+func TestClusterCollector(t *testing.T) {
+	lister := newLister(t,
+		newCluster("default", "a", corev1.ConditionTrue),
+		newCluster("default", "b", corev1.ConditionTrue),
+		newCluster("default", "c", corev1.ConditionFalse),
+		newCluster("default", "d", ""),
+		newCluster("other", "a", corev1.ConditionTrue),
+	)
+
+	synced := false
+	body := scrape(t, NewClusterCollector(lister, func() bool { return synced }))
+	if strings.Contains(body, "clusterregistry_clusters{") {
+		t.Errorf("Expected no inventory before the informer synced, got:\n%s", body)
+	}
+
+	synced = true
+	body = scrape(t, NewClusterCollector(lister, func() bool { return synced }))
+	for _, want := range []string{
+		`clusterregistry_clusters{namespace="default",status="True"} 2`,
+		`clusterregistry_clusters{namespace="default",status="False"} 1`,
+		`clusterregistry_clusters{namespace="default",status="Unknown"} 1`,
+		`clusterregistry_clusters{namespace="other",status="True"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in:\n%s", want, body)
+		}
+	}
+}
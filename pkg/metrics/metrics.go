@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of the metrics specific to the cluster
+// registry.
+const Namespace = "clusterregistry"
+
+// Registry is the registry of the metrics that are global to a process, e.g.
+// the workqueue and controller metrics. Go and process metrics are registered
+// with it.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(collectors.NewGoCollector())
+	Registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+// Handler returns an HTTP handler that serves the metrics of Registry and of
+// collectors in the Prometheus exposition format. collectors are typically
+// bound to the informers of a controller manager, and are registered with a
+// separate registry so that several handlers can serve different collectors.
+func Handler(collectors ...prometheus.Collector) (http.Handler, error) {
+	registry := prometheus.NewRegistry()
+	for _, collector := range collectors {
+		if err := registry.Register(collector); err != nil {
+			return nil, errors.Wrap(err, "failed to register collector")
+		}
+	}
+	gatherers := prometheus.Gatherers{Registry, registry}
+	return promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}), nil
+}
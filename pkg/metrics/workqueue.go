@@ -0,0 +1,122 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The workqueue metrics have the same names as in the Kubernetes controller
+// manager, so that the same dashboards and alerts apply to the controllers of
+// the cluster registry. They are labeled with the name of the queue, which is
+// the name of the controller for the queues created by
+// k8s.io/cluster-registry/pkg/controller.New.
+const workqueueSubsystem = "workqueue"
+
+var (
+	workqueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "depth",
+		Help:      "Current depth of workqueue",
+	}, []string{"name"})
+
+	workqueueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "adds_total",
+		Help:      "Total number of adds handled by workqueue",
+	}, []string{"name"})
+
+	workqueueLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "queue_duration_seconds",
+		Help:      "How long in seconds an item stays in workqueue before being requested",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name"})
+
+	workqueueWorkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "work_duration_seconds",
+		Help:      "How long in seconds processing an item from workqueue takes",
+		Buckets:   prometheus.ExponentialBuckets(10e-9, 10, 12),
+	}, []string{"name"})
+
+	workqueueUnfinishedWork = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "unfinished_work_seconds",
+		Help: "How many seconds of work has been done that is in progress and hasn't been observed by work_duration. " +
+			"Large values indicate stuck threads. One can deduce the number of stuck threads by observing the rate at which this increases.",
+	}, []string{"name"})
+
+	workqueueLongestRunningProcessor = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "longest_running_processor_seconds",
+		Help:      "How many seconds has the longest running processor for workqueue been running.",
+	}, []string{"name"})
+
+	workqueueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: workqueueSubsystem,
+		Name:      "retries_total",
+		Help:      "Total number of retries handled by workqueue",
+	}, []string{"name"})
+)
+
+func init() {
+	Registry.MustRegister(
+		workqueueDepth,
+		workqueueAdds,
+		workqueueLatency,
+		workqueueWorkDuration,
+		workqueueUnfinishedWork,
+		workqueueLongestRunningProcessor,
+		workqueueRetries,
+	)
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+// workqueueMetricsProvider provides the metrics of the named workqueues
+// created after this package is imported.
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return workqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return workqueueAdds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return workqueueLatency.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return workqueueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return workqueueUnfinishedWork.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return workqueueLongestRunningProcessor.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return workqueueRetries.WithLabelValues(name)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestWorkqueueMetrics(t *testing.T) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Hour, time.Hour), "metrics-test")
+	defer queue.ShutDown()
+	defer deleteWorkqueueMetrics("metrics-test")
+	queue.Add("default/cluster")
+	queue.AddRateLimited("default/other")
+
+	body := scrape(t)
+	for _, want := range []string{
+		`workqueue_adds_total{name="metrics-test"} 1`,
+		`workqueue_depth{name="metrics-test"} 1`,
+		`workqueue_retries_total{name="metrics-test"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %s in:\n%s", want, body)
+		}
+	}
+}
+
+// deleteWorkqueueMetrics deletes the metrics of the queue named name, so that
+// the tests using it can be repeated.
+func deleteWorkqueueMetrics(name string) {
+	for _, vec := range []interface{ DeleteLabelValues(...string) bool }{
+		workqueueDepth,
+		workqueueAdds,
+		workqueueLatency,
+		workqueueWorkDuration,
+		workqueueUnfinishedWork,
+		workqueueLongestRunningProcessor,
+		workqueueRetries,
+	} {
+		vec.DeleteLabelValues(name)
+	}
+}
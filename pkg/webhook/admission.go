@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+// admitFunc reviews an admission request and returns the response to send
+// back to the API server. The UID of the response is set by serveAdmission.
+type admitFunc func(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse
+
+// serveAdmission decodes the AdmissionReview in r, passes its request to admit
+// and writes the resulting AdmissionReview to w.
+func serveAdmission(w http.ResponseWriter, r *http.Request, admit admitFunc) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, fmt.Sprintf("could not decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = admit(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		klog.Errorf("Failed to write AdmissionReview response: %v", err)
+	}
+}
+
+// decodeCluster decodes the JSON-encoded Cluster in data, converting it to
+// v1alpha1 if needed.
+func decodeCluster(data []byte) (*v1alpha1.Cluster, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(data, typeMeta); err != nil {
+		return nil, errors.Wrap(err, "could not decode object")
+	}
+
+	switch typeMeta.APIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		cluster := &v1alpha1.Cluster{}
+		if err := json.Unmarshal(data, cluster); err != nil {
+			return nil, errors.Wrap(err, "could not decode Cluster")
+		}
+		return cluster, nil
+	case v1beta1.SchemeGroupVersion.String():
+		in := &v1beta1.Cluster{}
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, errors.Wrap(err, "could not decode Cluster")
+		}
+		cluster := &v1alpha1.Cluster{}
+		if err := v1beta1.Convert_v1beta1_Cluster_To_v1alpha1_Cluster(in, cluster); err != nil {
+			return nil, errors.Wrap(err, "could not convert Cluster")
+		}
+		return cluster, nil
+	default:
+		return nil, errors.Errorf("unsupported API version %q", typeMeta.APIVersion)
+	}
+}
+
+// errorResponse returns a response that rejects a request because of err.
+func errorResponse(err error) *admissionv1beta1.AdmissionResponse {
+	status := apierrors.NewBadRequest(err.Error()).ErrStatus
+	return &admissionv1beta1.AdmissionResponse{Allowed: false, Result: &status}
+}
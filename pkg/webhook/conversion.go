@@ -0,0 +1,156 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains the HTTP handlers of the webhooks that the API
+// server calls for the Cluster custom resource.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+// ConversionPath is the path at which the conversion webhook is served by
+// the webhook server, and to which the Cluster CRD sends ConversionReviews.
+const ConversionPath = "/convert"
+
+// maxRequestBytes bounds the size of the requests read by the webhooks.
+const maxRequestBytes = 3 * 1024 * 1024
+
+// conversionFunc converts a JSON-encoded Cluster from one version to another.
+type conversionFunc func(in []byte) (interface{}, error)
+
+// conversions holds the conversion functions between each pair of supported
+// versions, indexed by source and then destination API version.
+var conversions = map[string]map[string]conversionFunc{
+	v1alpha1.SchemeGroupVersion.String(): {
+		v1beta1.SchemeGroupVersion.String(): func(in []byte) (interface{}, error) {
+			cluster := &v1alpha1.Cluster{}
+			if err := json.Unmarshal(in, cluster); err != nil {
+				return nil, err
+			}
+			out := &v1beta1.Cluster{}
+			err := v1beta1.Convert_v1alpha1_Cluster_To_v1beta1_Cluster(cluster, out)
+			return out, err
+		},
+	},
+	v1beta1.SchemeGroupVersion.String(): {
+		v1alpha1.SchemeGroupVersion.String(): func(in []byte) (interface{}, error) {
+			cluster := &v1beta1.Cluster{}
+			if err := json.Unmarshal(in, cluster); err != nil {
+				return nil, err
+			}
+			out := &v1alpha1.Cluster{}
+			err := v1beta1.Convert_v1beta1_Cluster_To_v1alpha1_Cluster(cluster, out)
+			return out, err
+		},
+	},
+}
+
+// NewConversionHandler returns an HTTP handler that serves the ConversionReview
+// requests sent by the API server to convert Clusters between the versions of
+// the clusterregistry API.
+func NewConversionHandler() http.Handler {
+	return http.HandlerFunc(serveConversion)
+}
+
+func serveConversion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &apiextensionsv1beta1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, fmt.Sprintf("could not decode ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = Convert(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		klog.Errorf("Failed to write ConversionReview response: %v", err)
+	}
+}
+
+// Convert converts each of the objects in request to its desired API version.
+// If any object cannot be converted, the response has a Failure result and no
+// converted objects.
+func Convert(request *apiextensionsv1beta1.ConversionRequest) *apiextensionsv1beta1.ConversionResponse {
+	response := &apiextensionsv1beta1.ConversionResponse{UID: request.UID}
+	for i, object := range request.Objects {
+		converted, err := convertObject(object.Raw, request.DesiredAPIVersion)
+		if err != nil {
+			klog.V(2).Infof("Failed to convert object %d of ConversionReview %s: %v", i, request.UID, err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convertObject converts the JSON-encoded Cluster in to desiredAPIVersion.
+func convertObject(in []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(in, typeMeta); err != nil {
+		return nil, errors.Wrap(err, "could not decode object")
+	}
+	if typeMeta.Kind != "Cluster" {
+		return nil, errors.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return in, nil
+	}
+
+	convert, ok := conversions[typeMeta.APIVersion][desiredAPIVersion]
+	if !ok {
+		return nil, errors.Errorf("unsupported conversion from %q to %q", typeMeta.APIVersion, desiredAPIVersion)
+	}
+	out, err := convert(in)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert %s from %s to %s", typeMeta.Kind, typeMeta.APIVersion, desiredAPIVersion)
+	}
+	return json.Marshal(out)
+}
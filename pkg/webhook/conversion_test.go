@@ -0,0 +1,184 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+func mustMarshal(t *testing.T, obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return data
+}
+
+func newV1alpha1Cluster() *v1alpha1.Cluster {
+	cluster := &v1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{Kind: "Cluster", APIVersion: v1alpha1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "default",
+			Labels:    map[string]string{"env": "prod"},
+		},
+	}
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1alpha1.ServerAddressByClientCIDR{
+		{ClientCIDR: "0.0.0.0/0", ServerAddress: "cluster.example.com"},
+	}
+	cluster.Spec.AuthInfo.Controller = &v1alpha1.ObjectReference{Kind: "Secret", Name: "credentials"}
+	cluster.Status.Conditions = []v1alpha1.ClusterCondition{{Type: v1alpha1.ClusterOK, Status: "True"}}
+	return cluster
+}
+
+// review sends a ConversionReview for objects to the conversion handler and
+// returns the response.
+func review(t *testing.T, desiredAPIVersion string, objects ...[]byte) *apiextensionsv1beta1.ConversionResponse {
+	request := &apiextensionsv1beta1.ConversionReview{
+		Request: &apiextensionsv1beta1.ConversionRequest{
+			UID:               "uid",
+			DesiredAPIVersion: desiredAPIVersion,
+		},
+	}
+	for _, object := range objects {
+		request.Request.Objects = append(request.Request.Objects, runtime.RawExtension{Raw: object})
+	}
+
+	recorder := httptest.NewRecorder()
+	NewConversionHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConversionPath, bytes.NewReader(mustMarshal(t, request))))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	result := &apiextensionsv1beta1.ConversionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Response == nil {
+		t.Fatalf("Expected a response, got none")
+	}
+	if result.Response.UID != "uid" {
+		t.Errorf("Expected UID %q, got %q", "uid", result.Response.UID)
+	}
+	return result.Response
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	original := newV1alpha1Cluster()
+
+	response := review(t, v1beta1.SchemeGroupVersion.String(), mustMarshal(t, original))
+	if response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("Expected success, got %v", response.Result)
+	}
+	if len(response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected 1 converted object, got %d", len(response.ConvertedObjects))
+	}
+	converted := &v1beta1.Cluster{}
+	if err := json.Unmarshal(response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if converted.APIVersion != v1beta1.SchemeGroupVersion.String() || converted.Kind != "Cluster" {
+		t.Errorf("Expected a %s Cluster, got %s %s", v1beta1.SchemeGroupVersion, converted.APIVersion, converted.Kind)
+	}
+
+	response = review(t, v1alpha1.SchemeGroupVersion.String(), response.ConvertedObjects[0].Raw)
+	if response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("Expected success, got %v", response.Result)
+	}
+	roundTripped := &v1alpha1.Cluster{}
+	if err := json.Unmarshal(response.ConvertedObjects[0].Raw, roundTripped); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Errorf("Expected %#v, got %#v", original, roundTripped)
+	}
+}
+
+func TestConvertSameVersion(t *testing.T) {
+	object := mustMarshal(t, newV1alpha1Cluster())
+	response := review(t, v1alpha1.SchemeGroupVersion.String(), object)
+	if response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("Expected success, got %v", response.Result)
+	}
+	if !bytes.Equal(response.ConvertedObjects[0].Raw, object) {
+		t.Errorf("Expected the object to be returned unchanged, got %s", response.ConvertedObjects[0].Raw)
+	}
+}
+
+func TestConvertFailure(t *testing.T) {
+	unknownKind := mustMarshal(t, &metav1.TypeMeta{Kind: "Secret", APIVersion: v1alpha1.SchemeGroupVersion.String()})
+	// The objects of a ConversionReview are always valid JSON, so an invalid
+	// object is one that does not decode into a Cluster.
+	invalidObject := []byte(`{"kind":"Cluster","apiVersion":"clusterregistry.k8s.io/v1alpha1","spec":"invalid"}`)
+
+	tests := []struct {
+		name              string
+		desiredAPIVersion string
+		objects           [][]byte
+	}{
+		{"unknown desired version", "clusterregistry.k8s.io/v2", [][]byte{mustMarshal(t, newV1alpha1Cluster())}},
+		{"unknown kind", v1beta1.SchemeGroupVersion.String(), [][]byte{unknownKind}},
+		{"invalid object", v1beta1.SchemeGroupVersion.String(), [][]byte{mustMarshal(t, newV1alpha1Cluster()), invalidObject}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			response := review(t, tc.desiredAPIVersion, tc.objects...)
+			if response.Result.Status != metav1.StatusFailure {
+				t.Errorf("Expected failure, got %v", response.Result)
+			}
+			if len(response.ConvertedObjects) != 0 {
+				t.Errorf("Expected no converted objects, got %d", len(response.ConvertedObjects))
+			}
+		})
+	}
+}
+
+func TestConversionHandlerBadRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		code   int
+	}{
+		{"GET", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid JSON", http.MethodPost, "{", http.StatusBadRequest},
+		{"no request", http.MethodPost, "{}", http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewConversionHandler().ServeHTTP(recorder, httptest.NewRequest(tc.method, ConversionPath, bytes.NewReader([]byte(tc.body))))
+			if recorder.Code != tc.code {
+				t.Errorf("Expected status %d, got %d", tc.code, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+// DefaultingPath is the path at which the mutating admission webhook that
+// sets the default values of Clusters is served by the webhook server.
+const DefaultingPath = "/default"
+
+// jsonPatchOperation is an operation of a JSON Patch, as defined in RFC 6902.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// NewDefaultingHandler returns an HTTP handler that serves the
+// AdmissionReview requests sent by the API server to set the default values
+// of the Clusters that are created or updated.
+func NewDefaultingHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveAdmission(w, r, Default)
+	})
+}
+
+// Default reviews request, and patches the Cluster it creates or updates with
+// the defaults set by v1alpha1.SetDefaults_Cluster. The patch replaces the
+// whole spec of the Cluster, in the API version of the request. Requests are
+// always allowed unless the Cluster cannot be decoded.
+func Default(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if request.Operation != admissionv1beta1.Create && request.Operation != admissionv1beta1.Update {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	cluster, err := decodeCluster(request.Object.Raw)
+	if err != nil {
+		return errorResponse(err)
+	}
+	if cluster.Namespace == "" {
+		cluster.Namespace = request.Namespace
+	}
+	defaulted := cluster.DeepCopy()
+	v1alpha1.SetDefaults_Cluster(defaulted)
+	if apiequality.Semantic.DeepEqual(cluster.Spec, defaulted.Spec) {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+
+	spec, err := specForAPIVersion(&defaulted.Spec, request.Object.Raw)
+	if err != nil {
+		return errorResponse(err)
+	}
+	patch, err := json.Marshal([]jsonPatchOperation{{Op: "add", Path: "/spec", Value: spec}})
+	if err != nil {
+		return errorResponse(err)
+	}
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{Allowed: true, Patch: patch, PatchType: &patchType}
+}
+
+// specForAPIVersion converts spec to the API version of the JSON-encoded
+// Cluster in original.
+func specForAPIVersion(spec *v1alpha1.ClusterSpec, original []byte) (interface{}, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(original, typeMeta); err != nil {
+		return nil, errors.Wrap(err, "could not decode object")
+	}
+
+	switch typeMeta.APIVersion {
+	case v1alpha1.SchemeGroupVersion.String():
+		return spec, nil
+	case v1beta1.SchemeGroupVersion.String():
+		out := &v1beta1.ClusterSpec{}
+		if err := v1beta1.Convert_v1alpha1_ClusterSpec_To_v1beta1_ClusterSpec(spec, out); err != nil {
+			return nil, errors.Wrap(err, "could not convert ClusterSpec")
+		}
+		return out, nil
+	default:
+		return nil, errors.Errorf("unsupported API version %q", typeMeta.APIVersion)
+	}
+}
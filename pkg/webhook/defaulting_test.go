@@ -0,0 +1,149 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+// applyPatch applies the JSON patch in response to object.
+func applyPatch(t *testing.T, response *admissionv1beta1.AdmissionResponse, object []byte) []byte {
+	if response.PatchType == nil || *response.PatchType != admissionv1beta1.PatchTypeJSONPatch {
+		t.Fatalf("Expected a JSON patch, got %v", response.PatchType)
+	}
+	patch, err := jsonpatch.DecodePatch(response.Patch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	patched, err := patch.Apply(object)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return patched
+}
+
+func newUndefaultedCluster() *v1alpha1.Cluster {
+	cluster := newV1alpha1Cluster()
+	cluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1alpha1.ServerAddressByClientCIDR{{ServerAddress: "https://cluster.example.com"}}
+	return cluster
+}
+
+func TestDefault(t *testing.T) {
+	cluster := newUndefaultedCluster()
+	want := cluster.DeepCopy()
+	v1alpha1.SetDefaults_Cluster(want)
+
+	object := mustMarshal(t, cluster)
+	response := admissionReview(t, NewDefaultingHandler(), admissionv1beta1.Create, object, nil)
+	if !response.Allowed {
+		t.Fatalf("Expected the request to be allowed, got %v", response.Result)
+	}
+
+	patched := &v1alpha1.Cluster{}
+	if err := json.Unmarshal(applyPatch(t, response, object), patched); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(patched, want) {
+		t.Errorf("Expected %#v, got %#v", want, patched)
+	}
+}
+
+func TestDefaultV1beta1(t *testing.T) {
+	cluster := newUndefaultedCluster()
+	// The namespace of the object is unset when it is taken from the URL of
+	// the request.
+	cluster.Namespace = ""
+	cluster.Spec.AuthInfo.Controller.Namespace = ""
+	in := &v1beta1.Cluster{}
+	if err := v1beta1.Convert_v1alpha1_Cluster_To_v1beta1_Cluster(cluster, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	object := mustMarshal(t, in)
+	response := admissionReview(t, NewDefaultingHandler(), admissionv1beta1.Update, object, object)
+	if !response.Allowed {
+		t.Fatalf("Expected the request to be allowed, got %v", response.Result)
+	}
+
+	patched := &v1beta1.Cluster{}
+	if err := json.Unmarshal(applyPatch(t, response, object), patched); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if patched.APIVersion != v1beta1.SchemeGroupVersion.String() {
+		t.Errorf("Expected apiVersion %q, got %q", v1beta1.SchemeGroupVersion, patched.APIVersion)
+	}
+	if endpoints := patched.Spec.KubernetesAPIEndpoints.ServerEndpoints; len(endpoints) != 2 || endpoints[0].ServerAddress != "cluster.example.com:443" {
+		t.Errorf("Expected defaulted endpoints, got %v", endpoints)
+	}
+	if ref := patched.Spec.AuthInfo.Controller; ref.Namespace != "default" {
+		t.Errorf("Expected the controller reference to default to the request namespace, got %q", ref.Namespace)
+	}
+}
+
+func TestDefaultNoChange(t *testing.T) {
+	cluster := newUndefaultedCluster()
+	v1alpha1.SetDefaults_Cluster(cluster)
+
+	response := admissionReview(t, NewDefaultingHandler(), admissionv1beta1.Create, mustMarshal(t, cluster), nil)
+	if !response.Allowed {
+		t.Fatalf("Expected the request to be allowed, got %v", response.Result)
+	}
+	if len(response.Patch) != 0 || response.PatchType != nil {
+		t.Errorf("Expected no patch, got %s", response.Patch)
+	}
+}
+
+// TestDefaultLegacyUpdate relabels a Cluster registered with the legacy
+// clientCIDR 0.0.0.0, and checks that the defaulted object passes validation.
+func TestDefaultLegacyUpdate(t *testing.T) {
+	oldCluster := newV1alpha1Cluster()
+	oldCluster.ResourceVersion = "1"
+	oldCluster.Spec.KubernetesAPIEndpoints.ServerEndpoints = []v1alpha1.ServerAddressByClientCIDR{{ClientCIDR: "0.0.0.0", ServerAddress: "127.0.0.1"}}
+	cluster := oldCluster.DeepCopy()
+	cluster.Labels["team"] = "infra"
+
+	oldObject := mustMarshal(t, oldCluster)
+	object := mustMarshal(t, cluster)
+	response := admissionReview(t, NewDefaultingHandler(), admissionv1beta1.Update, object, oldObject)
+	if !response.Allowed {
+		t.Fatalf("Expected the request to be allowed, got %v", response.Result)
+	}
+	object = applyPatch(t, response, object)
+
+	patched := &v1alpha1.Cluster{}
+	if err := json.Unmarshal(object, patched); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []v1alpha1.ServerAddressByClientCIDR{{ClientCIDR: "0.0.0.0/0", ServerAddress: "127.0.0.1:443"}}
+	if endpoints := patched.Spec.KubernetesAPIEndpoints.ServerEndpoints; !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("Expected endpoints %v, got %v", want, endpoints)
+	}
+
+	response = admissionReview(t, NewValidationHandler(), admissionv1beta1.Update, object, oldObject)
+	if !response.Allowed {
+		t.Errorf("Expected the defaulted update to be allowed, got %v", response.Result)
+	}
+}
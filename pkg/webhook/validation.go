@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1/validation"
+)
+
+// ValidationPath is the path at which the validating admission webhook is
+// served by the webhook server.
+const ValidationPath = "/validate"
+
+// NewValidationHandler returns an HTTP handler that serves the AdmissionReview
+// requests sent by the API server to validate the Clusters that are created
+// or updated.
+func NewValidationHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveAdmission(w, r, Validate)
+	})
+}
+
+// Validate reviews request, and rejects it if the Cluster it creates or
+// updates is invalid. The result of a rejected request lists the invalid
+// fields. Other operations are always allowed.
+func Validate(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	var errs field.ErrorList
+	switch request.Operation {
+	case admissionv1beta1.Create:
+		cluster, err := decodeCluster(request.Object.Raw)
+		if err != nil {
+			return errorResponse(err)
+		}
+		errs = validation.ValidateCluster(cluster)
+	case admissionv1beta1.Update:
+		cluster, err := decodeCluster(request.Object.Raw)
+		if err != nil {
+			return errorResponse(err)
+		}
+		oldCluster, err := decodeCluster(request.OldObject.Raw)
+		if err != nil {
+			return errorResponse(err)
+		}
+		errs = validation.ValidateClusterUpdate(cluster, oldCluster)
+	}
+
+	if len(errs) == 0 {
+		return &admissionv1beta1.AdmissionResponse{Allowed: true}
+	}
+	status := apierrors.NewInvalid(v1alpha1.Kind("Cluster"), request.Name, errs).ErrStatus
+	return &admissionv1beta1.AdmissionResponse{Allowed: false, Result: &status}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1beta1"
+)
+
+// admissionReview sends an AdmissionReview for the given operation and
+// objects to handler and returns the response.
+func admissionReview(t *testing.T, handler http.Handler, operation admissionv1beta1.Operation, object, oldObject []byte) *admissionv1beta1.AdmissionResponse {
+	request := &admissionv1beta1.AdmissionReview{
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       "uid",
+			Name:      "cluster",
+			Namespace: "default",
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: object},
+			OldObject: runtime.RawExtension{Raw: oldObject},
+		},
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(mustMarshal(t, request))))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	result := &admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Response == nil {
+		t.Fatalf("Expected a response, got none")
+	}
+	if result.Response.UID != "uid" {
+		t.Errorf("Expected UID %q, got %q", "uid", result.Response.UID)
+	}
+	return result.Response
+}
+
+func TestValidate(t *testing.T) {
+	// Objects always have a resource version by the time they are updated.
+	valid := newV1alpha1Cluster()
+	valid.ResourceVersion = "1"
+
+	invalid := newV1alpha1Cluster()
+	invalid.ResourceVersion = "1"
+	invalid.Spec.KubernetesAPIEndpoints.ServerEndpoints[0].ClientCIDR = "garbage"
+	invalid.Spec.AuthInfo.Controller.Kind = "Pod"
+
+	validV1beta1 := &v1beta1.Cluster{}
+	if err := v1beta1.Convert_v1alpha1_Cluster_To_v1beta1_Cluster(valid, validV1beta1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	invalidV1beta1 := &v1beta1.Cluster{}
+	if err := v1beta1.Convert_v1alpha1_Cluster_To_v1beta1_Cluster(invalid, invalidV1beta1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		operation admissionv1beta1.Operation
+		object    interface{}
+		oldObject interface{}
+		allowed   bool
+		causes    []string
+	}{
+		{"valid create", admissionv1beta1.Create, valid, nil, true, nil},
+		{"valid v1beta1 create", admissionv1beta1.Create, validV1beta1, nil, true, nil},
+		{"valid update", admissionv1beta1.Update, valid, valid, true, nil},
+		{"invalid create", admissionv1beta1.Create, invalid, nil, false, []string{
+			"spec.kubernetesApiEndpoints.serverEndpoints[0].clientCIDR",
+			"spec.authInfo.controller.kind",
+		}},
+		{"invalid v1beta1 create", admissionv1beta1.Create, invalidV1beta1, nil, false, []string{
+			"spec.kubernetesApiEndpoints.serverEndpoints[0].clientCIDR",
+			"spec.authInfo.controller.kind",
+		}},
+		{"invalid update", admissionv1beta1.Update, invalid, valid, false, []string{
+			"spec.kubernetesApiEndpoints.serverEndpoints[0].clientCIDR",
+			"spec.authInfo.controller.kind",
+		}},
+		{"delete", admissionv1beta1.Delete, nil, invalid, true, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var object, oldObject []byte
+			if tc.object != nil {
+				object = mustMarshal(t, tc.object)
+			}
+			if tc.oldObject != nil {
+				oldObject = mustMarshal(t, tc.oldObject)
+			}
+
+			response := admissionReview(t, NewValidationHandler(), tc.operation, object, oldObject)
+			if response.Allowed != tc.allowed {
+				t.Fatalf("Expected allowed to be %v, got %v (%v)", tc.allowed, response.Allowed, response.Result)
+			}
+			if tc.allowed {
+				return
+			}
+			if response.Result == nil || response.Result.Reason != metav1.StatusReasonInvalid || response.Result.Details == nil {
+				t.Fatalf("Expected an Invalid result with details, got %v", response.Result)
+			}
+			var causes []string
+			for _, cause := range response.Result.Details.Causes {
+				causes = append(causes, cause.Field)
+			}
+			if len(causes) != len(tc.causes) {
+				t.Fatalf("Expected causes %v, got %v", tc.causes, causes)
+			}
+			for i := range causes {
+				if causes[i] != tc.causes[i] {
+					t.Errorf("Expected causes %v, got %v", tc.causes, causes)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateUndecodableObject(t *testing.T) {
+	object := mustMarshal(t, &metav1.TypeMeta{Kind: "Cluster", APIVersion: "clusterregistry.k8s.io/v2"})
+	response := admissionReview(t, NewValidationHandler(), admissionv1beta1.Create, object, nil)
+	if response.Allowed {
+		t.Errorf("Expected the request to be rejected")
+	}
+	if response.Result == nil || response.Result.Code != http.StatusBadRequest {
+		t.Errorf("Expected a BadRequest result, got %v", response.Result)
+	}
+}
@@ -17,19 +17,22 @@ limitations under the License.
 package integration
 
 import (
+	"context"
 	"testing"
 
-	"github.com/kubernetes-sigs/kubebuilder/pkg/test"
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cluster-registry/pkg/apis/clusterregistry"
 	"k8s.io/cluster-registry/pkg/apis/clusterregistry/v1alpha1"
+	crapplyv1beta1 "k8s.io/cluster-registry/pkg/client/applyconfiguration/clusterregistry/v1beta1"
 	crclientset "k8s.io/cluster-registry/pkg/client/clientset/versioned"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
 
 const testNamepace = "default"
 
 func TestClusterCRUD(t *testing.T) {
-	testenv := &test.TestEnvironment{CRDs: []*v1beta1.CustomResourceDefinition{&v1alpha1.ClusterCRD}}
+	testenv := &envtest.Environment{CRDs: []*apiextensionsv1.CustomResourceDefinition{clusterregistry.ClusterCRD.DeepCopy()}}
 
 	config, err := testenv.Start()
 	if err != nil {
@@ -52,22 +55,34 @@ func TestClusterCRUD(t *testing.T) {
 		testClusterGet(t, clientset, clusterName)
 	})
 
+	t.Run("GetV1beta1", func(t *testing.T) {
+		testClusterGetV1beta1(t, clientset, clusterName)
+	})
+
 	t.Run("Update", func(t *testing.T) {
 		testClusterUpdate(t, clientset, clusterName)
 	})
 
+	t.Run("UpdateStatus", func(t *testing.T) {
+		testClusterUpdateStatus(t, clientset, clusterName)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		testClusterApply(t, clientset, clusterName)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		testClusterDelete(t, clientset, clusterName)
 	})
 }
 
 func testClusterCreate(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
-	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Create(&v1alpha1.Cluster{
+	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Create(context.TODO(), &v1alpha1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      clusterName,
 			Namespace: testNamepace,
 		},
-	})
+	}, metav1.CreateOptions{})
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -79,7 +94,20 @@ func testClusterCreate(t *testing.T, clientset *crclientset.Clientset, clusterNa
 }
 
 func testClusterGet(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
-	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(clusterName,
+	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(context.TODO(), clusterName,
+		metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if cluster == nil {
+		t.Fatalf("Expected a cluster, got nil")
+	} else if cluster.Name != clusterName {
+		t.Fatalf("Expected a cluster named 'cluster', got a cluster named '%v'.", cluster.Name)
+	}
+}
+
+func testClusterGetV1beta1(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
+	cluster, err := clientset.ClusterregistryV1beta1().Clusters(testNamepace).Get(context.TODO(), clusterName,
 		metav1.GetOptions{})
 
 	if err != nil {
@@ -92,7 +120,7 @@ func testClusterGet(t *testing.T, clientset *crclientset.Clientset, clusterName
 }
 
 func testClusterUpdate(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
-	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(clusterName, metav1.GetOptions{})
+	cluster, err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(context.TODO(), clusterName, metav1.GetOptions{})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -107,7 +135,7 @@ func testClusterUpdate(t *testing.T, clientset *crclientset.Clientset, clusterNa
 		},
 	}
 
-	cluster, err = clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Update(cluster)
+	cluster, err = clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Update(context.TODO(), cluster, metav1.UpdateOptions{})
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
@@ -121,16 +149,74 @@ func testClusterUpdate(t *testing.T, clientset *crclientset.Clientset, clusterNa
 	}
 }
 
+func testClusterUpdateStatus(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
+	clusters := clientset.ClusterregistryV1alpha1().Clusters(testNamepace)
+	cluster, err := clusters.Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Status is a subresource, so writes to the main resource ignore it.
+	cluster.Status.ServerVersion = "v1.13.0"
+	cluster, err = clusters.Update(context.TODO(), cluster, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if cluster.Status.ServerVersion != "" {
+		t.Fatalf("Expected Update to ignore the status, got server version '%v'", cluster.Status.ServerVersion)
+	}
+
+	cluster.Status.ServerVersion = "v1.13.0"
+	cluster, err = clusters.UpdateStatus(context.TODO(), cluster, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if cluster.Status.ServerVersion != "v1.13.0" {
+		t.Fatalf("Expected server version 'v1.13.0', got '%v'", cluster.Status.ServerVersion)
+	}
+}
+
+func testClusterApply(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
+	const fieldManager = "integration-test"
+	clusters := clientset.ClusterregistryV1beta1().Clusters(testNamepace)
+
+	cluster, err := clusters.Apply(context.TODO(),
+		crapplyv1beta1.Cluster(clusterName, testNamepace).WithLabels(map[string]string{"env": "test"}),
+		metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if cluster.Labels["env"] != "test" {
+		t.Fatalf("Expected label env=test, got labels %v", cluster.Labels)
+	} else if cluster.Spec.AuthInfo.Controller == nil {
+		t.Fatalf("Expected Apply to keep the fields owned by other managers, got %v", cluster.Spec)
+	}
+
+	cluster, err = clusters.ApplyStatus(context.TODO(),
+		crapplyv1beta1.Cluster(clusterName, testNamepace).WithStatus(crapplyv1beta1.ClusterStatus().WithPlatform("test")),
+		metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if cluster.Status.Platform != "test" {
+		t.Fatalf("Expected platform 'test', got '%v'", cluster.Status.Platform)
+	}
+
+	var managed bool
+	for _, entry := range cluster.ManagedFields {
+		managed = managed || entry.Manager == fieldManager
+	}
+	if !managed {
+		t.Fatalf("Expected fields managed by '%v', got %v", fieldManager, cluster.ManagedFields)
+	}
+}
+
 func testClusterDelete(t *testing.T, clientset *crclientset.Clientset, clusterName string) {
-	err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Delete(clusterName,
-		&metav1.DeleteOptions{})
+	err := clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Delete(context.TODO(), clusterName,
+		metav1.DeleteOptions{})
 
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
 	// We do not expect to find the cluster we just deleted
-	_, err = clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(clusterName, metav1.GetOptions{})
+	_, err = clientset.ClusterregistryV1alpha1().Clusters(testNamepace).Get(context.TODO(), clusterName, metav1.GetOptions{})
 
 	if err == nil {
 		t.Fatalf("Unexpected error: %v", err)